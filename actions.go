@@ -2,6 +2,7 @@ package opslevel
 
 import (
 	"fmt"
+	"time"
 )
 
 type CustomActionsId struct {
@@ -64,6 +65,56 @@ func (customActionsTriggerDefinition *CustomActionsTriggerDefinition) ExtendedTe
 	return &q.Account.CustomActionsTriggerDefinition.ExtendedTeamAccess, nil
 }
 
+// CustomActionsTriggerInvokeInput specifies the input fields used in the `customActionsTriggerInvoke` mutation.
+type CustomActionsTriggerInvokeInput struct {
+	TriggerDefinition IdentifierInput `json:"triggerDefinition" yaml:"triggerDefinition" example:"{\"alias\": \"example_alias\"}"`       // The trigger definition to invoke. (Required.)
+	EntityId          *ID             `json:"entityId,omitempty" yaml:"entityId,omitempty" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"`    // The id of the entity the action is invoked on, omit for GLOBAL trigger definitions. (Optional.)
+	ManualInputs      *JSON           `json:"manualInputs,omitempty" yaml:"manualInputs,omitempty" example:"{\"reason\": \"rollback\"}"` // The values for the manual inputs of the trigger definition. (Optional.)
+}
+
+type CustomActionsTriggerEvent struct {
+	Definition   CustomActionsId                     `graphql:"definition"`
+	Id           ID                                  `graphql:"id"`
+	ManualInputs JSON                                `graphql:"manualInputs" scalar:"true"`
+	ResponseBody string                              `graphql:"responseBody"`
+	Status       CustomActionsTriggerEventStatusEnum `graphql:"status"`
+	Timestamps   Timestamps                          `graphql:"timestamps"`
+	TriggeredBy  UserId                              `graphql:"triggeredBy"`
+}
+
+type CustomActionsTriggerEventsConnection struct {
+	Nodes      []CustomActionsTriggerEvent
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+func (customActionsTriggerEvent *CustomActionsTriggerEvent) IsComplete() bool {
+	return customActionsTriggerEvent.Status != CustomActionsTriggerEventStatusEnumPending
+}
+
+// Invoke validates 'manualInputs' against the ManualInputsDefinition and then invokes the trigger definition
+func (customActionsTriggerDefinition *CustomActionsTriggerDefinition) Invoke(client *Client, entityId *ID, manualInputs JSON) (*CustomActionsTriggerEvent, error) {
+	if customActionsTriggerDefinition.Id == "" {
+		return nil, fmt.Errorf("unable to invoke, invalid CustomActionsTriggerDefinition id: '%s'", customActionsTriggerDefinition.Id)
+	}
+	definition, err := ParseManualInputsDefinition(customActionsTriggerDefinition.ManualInputsDefinition)
+	if err != nil {
+		return nil, err
+	}
+	manualInputs = definition.WithDefaults(manualInputs)
+	if err := definition.Validate(manualInputs); err != nil {
+		return nil, err
+	}
+	input := CustomActionsTriggerInvokeInput{
+		TriggerDefinition: *NewIdentifier(string(customActionsTriggerDefinition.Id)),
+		EntityId:          entityId,
+	}
+	if len(manualInputs) > 0 {
+		input.ManualInputs = &manualInputs
+	}
+	return client.InvokeTriggerDefinition(input)
+}
+
 type CustomActionsExternalActionsConnection struct {
 	Nodes      []CustomActionsExternalAction
 	PageInfo   PageInfo
@@ -244,3 +295,79 @@ func (client *Client) DeleteTriggerDefinition(input string) error {
 	err := client.Mutate(&m, v, WithName("TriggerDefinitionDelete"))
 	return HandleErrors(err, m.Payload.Errors)
 }
+
+func (client *Client) InvokeTriggerDefinition(input CustomActionsTriggerInvokeInput) (*CustomActionsTriggerEvent, error) {
+	var m struct {
+		Payload struct {
+			TriggerEvent CustomActionsTriggerEvent
+			Errors       []OpsLevelErrors
+		} `graphql:"customActionsTriggerInvoke(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("TriggerDefinitionInvoke"))
+	return &m.Payload.TriggerEvent, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) GetTriggerEvent(id ID) (*CustomActionsTriggerEvent, error) {
+	var q struct {
+		Account struct {
+			TriggerEvent CustomActionsTriggerEvent `graphql:"customActionsTriggerEvent(id: $id)"`
+		}
+	}
+	v := PayloadVariables{
+		"id": id,
+	}
+	err := client.Query(&q, v, WithName("TriggerEventGet"))
+	if q.Account.TriggerEvent.Id == "" {
+		err = fmt.Errorf("CustomActionsTriggerEvent with ID '%s' not found", id)
+	}
+	return &q.Account.TriggerEvent, HandleErrors(err, nil)
+}
+
+func (client *Client) ListTriggerEvents(variables *PayloadVariables) (*CustomActionsTriggerEventsConnection, error) {
+	var q struct {
+		Account struct {
+			TriggerEvents CustomActionsTriggerEventsConnection `graphql:"customActionsTriggerEvents(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("TriggerEventList")); err != nil {
+		return nil, err
+	}
+	for q.Account.TriggerEvents.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.TriggerEvents.PageInfo.End
+		resp, err := client.ListTriggerEvents(variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.TriggerEvents.Nodes = append(q.Account.TriggerEvents.Nodes, resp.Nodes...)
+		q.Account.TriggerEvents.PageInfo = resp.PageInfo
+		q.Account.TriggerEvents.TotalCount += resp.TotalCount
+	}
+	return &q.Account.TriggerEvents, nil
+}
+
+// WaitForTriggerEvent polls the trigger event every 'interval' until it is no longer pending or 'timeout' elapses
+func (client *Client) WaitForTriggerEvent(id ID, interval time.Duration, timeout time.Duration) (*CustomActionsTriggerEvent, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval to poll CustomActionsTriggerEvent '%s' must be positive. Given: '%s'", id, interval)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		event, err := client.GetTriggerEvent(id)
+		if err != nil {
+			return nil, err
+		}
+		if event.IsComplete() {
+			return event, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return event, fmt.Errorf("CustomActionsTriggerEvent '%s' still %s after %s", id, event.Status, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
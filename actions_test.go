@@ -1,7 +1,9 @@
 package opslevel_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	ol "github.com/opslevel/opslevel-go/v2024"
 	"github.com/rocktavious/autopilot/v2023"
@@ -346,12 +348,12 @@ func TestDeleteTriggerDefinition(t *testing.T) {
 func TestListExtendedTeamAccess(t *testing.T) {
 	// Arrange
 	testRequestOne := autopilot.NewTestRequest(
-		`query ExtendedTeamAccessList($after:String!$first:Int!$input:IdentifierInput!){account{customActionsTriggerDefinition(input: $input){extendedTeamAccess(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}}`,
+		`query ExtendedTeamAccessList($after:String!$first:Int!$input:IdentifierInput!){account{customActionsTriggerDefinition(input: $input){extendedTeamAccess(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}}`,
 		`{{ template "extended_team_access_get_vars_1" }}`,
 		`{{ template "extended_team_access_response_1" }}`,
 	)
 	testRequestTwo := autopilot.NewTestRequest(
-		`query ExtendedTeamAccessList($after:String!$first:Int!$input:IdentifierInput!){account{customActionsTriggerDefinition(input: $input){extendedTeamAccess(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}}`,
+		`query ExtendedTeamAccessList($after:String!$first:Int!$input:IdentifierInput!){account{customActionsTriggerDefinition(input: $input){extendedTeamAccess(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}}`,
 		`{{ template "extended_team_access_get_vars_2" }}`,
 		`{{ template "extended_team_access_response_2" }}`,
 	)
@@ -370,3 +372,161 @@ func TestListExtendedTeamAccess(t *testing.T) {
 	autopilot.Equals(t, "example", result[0].Alias)
 	autopilot.Equals(t, id1, result[0].TeamId.Id)
 }
+
+func TestInvokeTriggerDefinition(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation TriggerDefinitionInvoke($input:CustomActionsTriggerInvokeInput!){customActionsTriggerInvoke(input: $input){triggerEvent{{ template "custom_actions_trigger_event_request" }},errors{message,path}}}`,
+		`{"input":{"triggerDefinition":{"alias":"rollback"},"entityId":"{{ template "id3_string" }}","manualInputs":"{\"reason\":\"bad deploy\"}"}}`,
+		`{"data": {"customActionsTriggerInvoke": { "triggerEvent": {{ template "custom_action_trigger_event_pending" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "custom_actions/invoke_trigger", testRequest)
+	// Act
+	event, err := client.InvokeTriggerDefinition(ol.CustomActionsTriggerInvokeInput{
+		TriggerDefinition: *ol.NewIdentifier("rollback"),
+		EntityId:          &id3,
+		ManualInputs:      &ol.JSON{"reason": "bad deploy"},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id2, event.Id)
+	autopilot.Equals(t, ol.CustomActionsTriggerEventStatusEnumPending, event.Status)
+	autopilot.Equals(t, "bad deploy", event.ManualInputs["reason"])
+	autopilot.Equals(t, "kyle@opslevel.com", event.TriggeredBy.Email)
+}
+
+func TestTriggerDefinitionInvoke(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation TriggerDefinitionInvoke($input:CustomActionsTriggerInvokeInput!){customActionsTriggerInvoke(input: $input){triggerEvent{{ template "custom_actions_trigger_event_request" }},errors{message,path}}}`,
+		`{"input":{"triggerDefinition":{"id":"{{ template "id1_string" }}"},"manualInputs":"{\"reason\":\"bad deploy\"}"}}`,
+		`{"data": {"customActionsTriggerInvoke": { "triggerEvent": {{ template "custom_action_trigger_event_pending" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "custom_actions/trigger_definition_invoke", testRequest)
+	definition := ol.CustomActionsTriggerDefinition{
+		Id:                     id1,
+		ManualInputsDefinition: "version: 1\ninputs:\n  - identifier: reason\n    displayName: Reason\n    type: text_input\n    required: true\n",
+	}
+	// Act
+	event, err := definition.Invoke(client, nil, ol.JSON{"reason": "bad deploy"})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id2, event.Id)
+}
+
+func TestTriggerDefinitionInvokeWithoutManualInputs(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation TriggerDefinitionInvoke($input:CustomActionsTriggerInvokeInput!){customActionsTriggerInvoke(input: $input){triggerEvent{{ template "custom_actions_trigger_event_request" }},errors{message,path}}}`,
+		`{"input":{"triggerDefinition":{"id":"{{ template "id1_string" }}"},"entityId":"{{ template "id3_string" }}"}}`,
+		`{"data": {"customActionsTriggerInvoke": { "triggerEvent": {{ template "custom_action_trigger_event_pending" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "custom_actions/trigger_definition_invoke_without_manual_inputs", testRequest)
+	definition := ol.CustomActionsTriggerDefinition{
+		Id:                     id1,
+		ManualInputsDefinition: "",
+	}
+	// Act
+	event, err := definition.Invoke(client, &id3, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id2, event.Id)
+}
+
+func TestTriggerDefinitionInvokeInvalidInputs(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "custom_actions/trigger_definition_invoke_invalid")
+	definition := ol.CustomActionsTriggerDefinition{
+		Id:                     id1,
+		ManualInputsDefinition: "version: 1\ninputs:\n  - identifier: reason\n    displayName: Reason\n    type: text_input\n    required: true\n",
+	}
+	// Act
+	_, err := definition.Invoke(client, nil, ol.JSON{"ticket": "INC-1"})
+	// Assert
+	autopilot.Equals(t, "manual input 'ticket' is not defined\nmanual input 'reason' is required", err.Error())
+}
+
+func TestGetTriggerEvent(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query TriggerEventGet($id:ID!){account{customActionsTriggerEvent(id: $id){{ template "custom_actions_trigger_event_request" }}}}`,
+		`{"id":"{{ template "id2_string" }}"}`,
+		`{"data": {"account": { "customActionsTriggerEvent": {{ template "custom_action_trigger_event_success" }} }}}`,
+	)
+	client := BestTestClient(t, "custom_actions/get_trigger_event", testRequest)
+	// Act
+	event, err := client.GetTriggerEvent(id2)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, true, event.IsComplete())
+	autopilot.Equals(t, "{\"pipeline\": 42}", event.ResponseBody)
+}
+
+func TestListTriggerEvents(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query TriggerEventList($after:String!$first:Int!){account{customActionsTriggerEvents(after: $after, first: $first){nodes{{ template "custom_actions_trigger_event_request" }},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "customActionsTriggerEvents": { "nodes": [ {{ template "custom_action_trigger_event_pending" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query TriggerEventList($after:String!$first:Int!){account{customActionsTriggerEvents(after: $after, first: $first){nodes{{ template "custom_actions_trigger_event_request" }},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_second_query_variables" }}`,
+		`{ "data": { "account": { "customActionsTriggerEvents": { "nodes": [ {{ template "custom_action_trigger_event_success" }} ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "custom_actions/list_trigger_events", requests...)
+	// Act
+	response, err := client.ListTriggerEvents(nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, ol.CustomActionsTriggerEventStatusEnumSuccess, response.Nodes[1].Status)
+}
+
+func TestWaitForTriggerEvent(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query TriggerEventGet($id:ID!){account{customActionsTriggerEvent(id: $id){{ template "custom_actions_trigger_event_request" }}}}`,
+		`{"id":"{{ template "id2_string" }}"}`,
+		`{"data": {"account": { "customActionsTriggerEvent": {{ template "custom_action_trigger_event_pending" }} }}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query TriggerEventGet($id:ID!){account{customActionsTriggerEvent(id: $id){{ template "custom_actions_trigger_event_request" }}}}`,
+		`{"id":"{{ template "id2_string" }}"}`,
+		`{"data": {"account": { "customActionsTriggerEvent": {{ template "custom_action_trigger_event_success" }} }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "custom_actions/wait_trigger_event", requests...)
+	// Act
+	event, err := client.WaitForTriggerEvent(id2, time.Millisecond, time.Second)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, ol.CustomActionsTriggerEventStatusEnumSuccess, event.Status)
+}
+
+func TestWaitForTriggerEventTimeout(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query TriggerEventGet($id:ID!){account{customActionsTriggerEvent(id: $id){{ template "custom_actions_trigger_event_request" }}}}`,
+		`{"id":"{{ template "id2_string" }}"}`,
+		`{"data": {"account": { "customActionsTriggerEvent": {{ template "custom_action_trigger_event_pending" }} }}}`,
+	)
+	client := BestTestClient(t, "custom_actions/wait_trigger_event_timeout", testRequest)
+	// Act
+	event, err := client.WaitForTriggerEvent(id2, time.Second, 0)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected a timeout error")
+	autopilot.Equals(t, ol.CustomActionsTriggerEventStatusEnumPending, event.Status)
+}
+
+func TestWaitForTriggerEventInvalidInterval(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "custom_actions/wait_trigger_event_invalid_interval")
+	// Act
+	_, err := client.WaitForTriggerEvent(id2, 0, time.Second)
+	// Assert
+	autopilot.Equals(t, fmt.Sprintf("interval to poll CustomActionsTriggerEvent '%s' must be positive. Given: '0s'", id2), err.Error())
+}
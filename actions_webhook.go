@@ -0,0 +1,206 @@
+package opslevel
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CustomActionRequest is a decoded webhook sent by a CustomActionsWebhookAction
+type CustomActionRequest struct {
+	Alias   string // The alias the request was routed by
+	Payload JSON   // The body rendered from the action's LiquidTemplate
+	Headers http.Header
+	Request *http.Request
+}
+
+// CustomActionResponse is written back to OpsLevel, where Body is available to the trigger
+// definition's ResponseTemplate as 'response'
+type CustomActionResponse struct {
+	StatusCode int
+	Body       any
+}
+
+type CustomActionHandlerFunc func(ctx context.Context, request CustomActionRequest) (*CustomActionResponse, error)
+
+// CustomActionsWebhookHandler is an http.Handler that receives custom action webhooks and
+// routes them by action alias to the registered handlers.
+//
+// The alias is read from the AliasKey field of the JSON payload and falls back to the last
+// segment of the request path, so both `{"action": "restart"}` and `POST /actions/restart` work.
+type CustomActionsWebhookHandler struct {
+	AliasKey     string // The payload key holding the action alias, defaults to "action"
+	SecretHeader string // When set requests must send this header with Secret as its value
+	Secret       string
+	MaxBodyBytes int64 // Defaults to 1MB
+
+	mu       sync.RWMutex
+	handlers map[string]CustomActionHandlerFunc
+}
+
+func NewCustomActionsWebhookHandler() *CustomActionsWebhookHandler {
+	return &CustomActionsWebhookHandler{
+		AliasKey:     "action",
+		MaxBodyBytes: 1 << 20,
+		handlers:     map[string]CustomActionHandlerFunc{},
+	}
+}
+
+// WithSharedSecret verifies requests using the value 'header' is set to in the action's Headers
+func (handler *CustomActionsWebhookHandler) WithSharedSecret(action CustomActionsWebhookAction, header string) error {
+	for key, value := range action.Headers {
+		if !strings.EqualFold(key, header) {
+			continue
+		}
+		secret, ok := value.(string)
+		if !ok || secret == "" {
+			return fmt.Errorf("header '%s' of the webhook action must be a non empty string", header)
+		}
+		handler.SecretHeader = header
+		handler.Secret = secret
+		return nil
+	}
+	return fmt.Errorf("header '%s' is not set on the webhook action", header)
+}
+
+// Handle registers 'fn' for every given alias, replacing any handler already registered for it
+func (handler *CustomActionsWebhookHandler) Handle(fn CustomActionHandlerFunc, aliases ...string) {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if handler.handlers == nil {
+		handler.handlers = map[string]CustomActionHandlerFunc{}
+	}
+	for _, alias := range aliases {
+		handler.handlers[alias] = fn
+	}
+}
+
+func (handler *CustomActionsWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeCustomActionResponse(w, customActionError(http.StatusMethodNotAllowed, fmt.Sprintf("method '%s' is not allowed", r.Method)))
+		return
+	}
+	if handler.SecretHeader != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(handler.SecretHeader)), []byte(handler.Secret)) != 1 {
+		writeCustomActionResponse(w, customActionError(http.StatusUnauthorized, "invalid or missing shared secret"))
+		return
+	}
+
+	payload, err := handler.decode(r)
+	if err != nil {
+		writeCustomActionResponse(w, customActionError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	alias := handler.alias(r, payload)
+	handler.mu.RLock()
+	fn, ok := handler.handlers[alias]
+	handler.mu.RUnlock()
+	if !ok {
+		writeCustomActionResponse(w, customActionError(http.StatusNotFound, fmt.Sprintf("no handler registered for action '%s'", alias)))
+		return
+	}
+
+	response, err := fn(r.Context(), CustomActionRequest{
+		Alias:   alias,
+		Payload: payload,
+		Headers: r.Header,
+		Request: r,
+	})
+	if err != nil {
+		// Handler errors can hold internal details, log them instead of sending them back
+		log.Error().Err(err).Str("action", alias).Msg("custom action handler failed")
+		writeCustomActionResponse(w, customActionError(http.StatusInternalServerError, "internal error"))
+		return
+	}
+	if response == nil {
+		response = &CustomActionResponse{StatusCode: http.StatusNoContent}
+	}
+	writeCustomActionResponse(w, response)
+}
+
+func (handler *CustomActionsWebhookHandler) decode(r *http.Request) (JSON, error) {
+	limit := handler.MaxBodyBytes
+	if limit <= 0 {
+		limit = 1 << 20
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read request body: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("request body is larger than %d bytes", limit)
+	}
+	payload := JSON{}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return payload, nil
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("request body must be a json object: %w", err)
+	}
+	return payload, nil
+}
+
+func (handler *CustomActionsWebhookHandler) alias(r *http.Request, payload JSON) string {
+	key := handler.AliasKey
+	if key == "" {
+		key = "action"
+	}
+	if alias, ok := payload[key].(string); ok && alias != "" {
+		return alias
+	}
+	return path.Base(path.Clean("/" + r.URL.Path))
+}
+
+func customActionError(statusCode int, message string) *CustomActionResponse {
+	return &CustomActionResponse{
+		StatusCode: statusCode,
+		Body:       map[string]any{"error": message},
+	}
+}
+
+func writeCustomActionResponse(w http.ResponseWriter, response *CustomActionResponse) {
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	if response.Body == nil {
+		w.WriteHeader(statusCode)
+		return
+	}
+	body := response.Body
+	// JSON marshals to a quoted string for the GraphQL API, send it as a plain object instead
+	if jsonObject, ok := body.(JSON); ok {
+		body = map[string]any(jsonObject)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		log.Error().Err(err).Msg("unable to marshal custom action response")
+		statusCode = http.StatusInternalServerError
+		data, _ = json.Marshal(map[string]any{"error": "internal error"})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(data)
+}
+
+// LiquidResponse returns the value a ResponseTemplate sees as 'response', for use with LiquidContext
+func (response *CustomActionResponse) LiquidResponse() map[string]any {
+	body := response.Body
+	if jsonObject, ok := body.(JSON); ok {
+		body = map[string]any(jsonObject)
+	}
+	body, _ = toLiquidValue(body)
+	return map[string]any{
+		"status": response.StatusCode,
+		"body":   body,
+	}
+}
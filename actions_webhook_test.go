@@ -0,0 +1,88 @@
+package opslevel_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func newTestWebhookHandler(t *testing.T) *ol.CustomActionsWebhookHandler {
+	handler := ol.NewCustomActionsWebhookHandler()
+	handler.Handle(func(ctx context.Context, request ol.CustomActionRequest) (*ol.CustomActionResponse, error) {
+		return &ol.CustomActionResponse{
+			StatusCode: http.StatusCreated,
+			Body:       ol.JSON{"restarted": request.Payload["service"]},
+		}, nil
+	}, "restart", "restart_service")
+	handler.Handle(func(ctx context.Context, request ol.CustomActionRequest) (*ol.CustomActionResponse, error) {
+		return nil, errors.New("deploy failed")
+	}, "deploy")
+	action := ol.CustomActionsWebhookAction{Headers: ol.JSON{"X-Shared-Secret": "s3cret"}}
+	autopilot.Ok(t, handler.WithSharedSecret(action, "x-shared-secret"))
+	return handler
+}
+
+func TestCustomActionsWebhookHandler(t *testing.T) {
+	testcases := map[string]struct {
+		method   string
+		path     string
+		secret   string
+		body     string
+		status   int
+		response string
+	}{
+		"route by payload": {http.MethodPost, "/", "s3cret", `{"action": "restart", "service": "catalog"}`, http.StatusCreated, `{"restarted":"catalog"}`},
+		"route by path":    {http.MethodPost, "/actions/restart_service", "s3cret", `{"service": "catalog"}`, http.StatusCreated, `{"restarted":"catalog"}`},
+		"missing secret":   {http.MethodPost, "/", "", `{"action": "restart"}`, http.StatusUnauthorized, `{"error":"invalid or missing shared secret"}`},
+		"invalid body":     {http.MethodPost, "/", "s3cret", `[1, 2]`, http.StatusBadRequest, ``},
+		"unknown action":   {http.MethodPost, "/", "s3cret", `{"action": "rollback"}`, http.StatusNotFound, `{"error":"no handler registered for action 'rollback'"}`},
+		"handler error":    {http.MethodPost, "/", "s3cret", `{"action": "deploy"}`, http.StatusInternalServerError, `{"error":"internal error"}`},
+		"wrong method":     {http.MethodGet, "/actions/restart", "s3cret", ``, http.StatusMethodNotAllowed, `{"error":"method 'GET' is not allowed"}`},
+	}
+	handler := newTestWebhookHandler(t)
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.secret != "" {
+				request.Header.Set("X-Shared-Secret", tc.secret)
+			}
+			recorder := httptest.NewRecorder()
+			// Act
+			handler.ServeHTTP(recorder, request)
+			// Assert
+			autopilot.Equals(t, tc.status, recorder.Code)
+			if tc.response != "" {
+				autopilot.Equals(t, tc.response, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestCustomActionsWebhookHandlerMissingSecretHeader(t *testing.T) {
+	// Arrange
+	handler := ol.NewCustomActionsWebhookHandler()
+	// Act
+	err := handler.WithSharedSecret(ol.CustomActionsWebhookAction{Headers: ol.JSON{}}, "X-Shared-Secret")
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error")
+}
+
+func TestCustomActionResponsePreview(t *testing.T) {
+	// Arrange
+	definition := ol.CustomActionsTriggerDefinition{
+		ResponseTemplate: `{% if response.status == 201 %}Restarted {{ response.body.restarted }}{% endif %}`,
+	}
+	response := ol.CustomActionResponse{StatusCode: http.StatusCreated, Body: ol.JSON{"restarted": "catalog"}}
+	// Act
+	result, err := definition.PreviewResponse(ol.LiquidContext{Response: response.LiquidResponse()})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Restarted catalog", result)
+}
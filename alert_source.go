@@ -1,5 +1,11 @@
 package opslevel
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
 type AlertSource struct {
 	Description string              `graphql:"description"`
 	ExternalId  string              `graphql:"externalId"`
@@ -84,3 +90,191 @@ func (client *Client) DeleteAlertSourceService(id ID) error {
 	err := client.Mutate(&m, v, WithName("AlertSourceServiceDelete"))
 	return HandleErrors(err, m.Payload.Errors)
 }
+
+type AlertSourceConnection struct {
+	Nodes      []AlertSource
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type AlertSourceServiceConnection struct {
+	Nodes      []AlertSourceService
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+// AlertSourceSyncIntegration is an integration that supports manually syncing its alert sources
+type AlertSourceSyncIntegration struct {
+	IntegrationId
+	ManualAlertSourceSync `graphql:"... on ManualAlertSourceSync"`
+}
+
+// AlertSourceReconcileResult holds the alert source links changed while reconciling a service
+type AlertSourceReconcileResult struct {
+	Created []AlertSourceService
+	Deleted []AlertSourceService
+}
+
+// WithStatus returns the alert sources attached to the service that report the given status
+func (alertSourceServiceConnection *AlertSourceServiceConnection) WithStatus(status AlertSourceStatusTypeEnum) []AlertSourceService {
+	var output []AlertSourceService
+	for _, node := range alertSourceServiceConnection.Nodes {
+		if node.Status == status {
+			output = append(output, node)
+		}
+	}
+	return output
+}
+
+func (client *Client) ListAlertSources(variables *PayloadVariables) (*AlertSourceConnection, error) {
+	var q struct {
+		Account struct {
+			AlertSources AlertSourceConnection `graphql:"alertSources(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("AlertSourceList")); err != nil {
+		return nil, err
+	}
+	for q.Account.AlertSources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.AlertSources.PageInfo.End
+		resp, err := client.ListAlertSources(variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.AlertSources.Nodes = append(q.Account.AlertSources.Nodes, resp.Nodes...)
+		q.Account.AlertSources.PageInfo = resp.PageInfo
+		q.Account.AlertSources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.AlertSources, nil
+}
+
+func (client *Client) ListAlertSourcesWithType(alertSourceType AlertSourceTypeEnum, variables *PayloadVariables) (*AlertSourceConnection, error) {
+	var q struct {
+		Account struct {
+			AlertSources AlertSourceConnection `graphql:"alertSources(type: $type, after: $after, first: $first)"`
+		}
+	}
+	if !slices.Contains(AllAlertSourceTypeEnum, string(alertSourceType)) {
+		return nil, fmt.Errorf("alert source type must be one of %v. Given: '%s'", AllAlertSourceTypeEnum, alertSourceType)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["type"] = alertSourceType
+	if err := client.Query(&q, *variables, WithName("AlertSourceListWithType")); err != nil {
+		return nil, err
+	}
+	for q.Account.AlertSources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.AlertSources.PageInfo.End
+		resp, err := client.ListAlertSourcesWithType(alertSourceType, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.AlertSources.Nodes = append(q.Account.AlertSources.Nodes, resp.Nodes...)
+		q.Account.AlertSources.PageInfo = resp.PageInfo
+		q.Account.AlertSources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.AlertSources, nil
+}
+
+// SyncAlertSources triggers a manual sync of the alert sources of the given integration
+func (client *Client) SyncAlertSources(integration string) (*AlertSourceSyncIntegration, error) {
+	var m struct {
+		Payload struct {
+			Integration AlertSourceSyncIntegration
+			Errors      []OpsLevelErrors
+		} `graphql:"integrationSyncAlertSources(integration: $integration)"`
+	}
+	v := PayloadVariables{
+		"integration": *NewIdentifier(integration),
+	}
+	err := client.Mutate(&m, v, WithName("IntegrationSyncAlertSources"))
+	return &m.Payload.Integration, HandleErrors(err, m.Payload.Errors)
+}
+
+func (service *Service) GetAlertSources(client *Client, variables *PayloadVariables) (*AlertSourceServiceConnection, error) {
+	var q struct {
+		Account struct {
+			Service struct {
+				AlertSources AlertSourceServiceConnection `graphql:"alertSources(after: $after, first: $first)"`
+			} `graphql:"service(id: $service)"`
+		}
+	}
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to get AlertSources, invalid service id: '%s'", service.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["service"] = service.Id
+	if err := client.Query(&q, *variables, WithName("ServiceAlertSourcesList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Service.AlertSources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Service.AlertSources.PageInfo.End
+		resp, err := service.GetAlertSources(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Service.AlertSources.Nodes = append(q.Account.Service.AlertSources.Nodes, resp.Nodes...)
+		q.Account.Service.AlertSources.PageInfo = resp.PageInfo
+		q.Account.Service.AlertSources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Service.AlertSources, nil
+}
+
+// ReconcileAlertSources attaches the 'desired' alert sources to the service and
+// detaches every other alert source currently attached to it
+//
+// A failed attach or detach does not stop the others, their errors are joined and returned with the partial result.
+func (service *Service) ReconcileAlertSources(client *Client, desired []AlertSourceExternalIdentifier) (*AlertSourceReconcileResult, error) {
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to reconcile AlertSources, invalid service id: '%s'", service.Id)
+	}
+	current, err := service.GetAlertSources(client, nil)
+	if err != nil {
+		return nil, err
+	}
+	key := func(alertSourceType AlertSourceTypeEnum, externalId string) string {
+		return string(alertSourceType) + "/" + externalId
+	}
+	wanted := map[string]bool{}
+	for _, alertSource := range desired {
+		wanted[key(alertSource.Type, alertSource.ExternalId)] = true
+	}
+	var allErrors error
+	attached := map[string]bool{}
+	output := &AlertSourceReconcileResult{}
+	for _, alertSourceService := range current.Nodes {
+		alertSourceKey := key(alertSourceService.AlertSource.Type, alertSourceService.AlertSource.ExternalId)
+		if wanted[alertSourceKey] {
+			attached[alertSourceKey] = true
+			continue
+		}
+		if err := client.DeleteAlertSourceService(alertSourceService.Id); err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		output.Deleted = append(output.Deleted, alertSourceService)
+	}
+	for _, alertSource := range desired {
+		alertSourceKey := key(alertSource.Type, alertSource.ExternalId)
+		if attached[alertSourceKey] {
+			continue
+		}
+		created, err := client.CreateAlertSourceService(AlertSourceServiceCreateInput{
+			Service:                       *NewIdentifier(string(service.Id)),
+			AlertSourceExternalIdentifier: NewAlertSource(alertSource.Type, alertSource.ExternalId),
+		})
+		if err != nil {
+			allErrors = errors.Join(allErrors, fmt.Errorf("unable to attach alert source '%s': %w", alertSourceKey, err))
+			continue
+		}
+		attached[alertSourceKey] = true
+		output.Created = append(output.Created, *created)
+	}
+	return output, allErrors
+}
@@ -111,3 +111,167 @@ func TestDeleteAlertSourceService(t *testing.T) {
 	// Assert
 	autopilot.Equals(t, nil, err)
 }
+
+func TestListAlertSources(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query AlertSourceList($after:String!$first:Int!){account{alertSources(after: $after, first: $first){nodes{ {{- template "alert_source_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{"data": {"account": {"alertSources": {"nodes": [{{ template "alert_source_1" }}], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query AlertSourceList($after:String!$first:Int!){account{alertSources(after: $after, first: $first){nodes{ {{- template "alert_source_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_second_query_variables" }}`,
+		`{"data": {"account": {"alertSources": {"nodes": [{{ template "alert_source_2" }}], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "alert_source/list", requests...)
+	// Act
+	response, err := client.ListAlertSources(nil)
+	result := response.Nodes
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, "Checkout Latency", result[0].Name)
+	autopilot.Equals(t, ol.AlertSourceTypeEnumDatadog, result[1].Type)
+}
+
+func TestListAlertSourcesWithType(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query AlertSourceListWithType($after:String!$first:Int!$type:AlertSourceTypeEnum!){account{alertSources(type: $type, after: $after, first: $first){nodes{ {{- template "alert_source_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{ "after": "", "first": 100, "type": "pagerduty" }`,
+		`{"data": {"account": {"alertSources": {"nodes": [{{ template "alert_source_1" }}], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+
+	client := BestTestClient(t, "alert_source/list_with_type", testRequest)
+	// Act
+	response, err := client.ListAlertSourcesWithType(ol.AlertSourceTypeEnumPagerduty, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, response.TotalCount)
+	autopilot.Equals(t, "PD-111", response.Nodes[0].ExternalId)
+}
+
+func TestListAlertSourcesWithInvalidType(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "alert_source/list_with_invalid_type")
+	// Act
+	_, err := client.ListAlertSourcesWithType(ol.AlertSourceTypeEnum("splunk"), nil)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an invalid alert source type")
+}
+
+func TestSyncAlertSources(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation IntegrationSyncAlertSources($integration:IdentifierInput!){integrationSyncAlertSources(integration: $integration){integration{id,name,type,... on ManualAlertSourceSync{allowManualSyncAlertSources,lastManualSyncAlertSources}},errors{message,path}}}`,
+		`{"integration": { {{ template "id2" }} }}`,
+		`{"data": {"integrationSyncAlertSources": {"integration": { {{ template "id2" }}, "name": "PagerDuty", "type": "pagerduty", "allowManualSyncAlertSources": "true", "lastManualSyncAlertSources": "2026-10-19T12:00:00Z" }, "errors": [] }}}`,
+	)
+
+	client := BestTestClient(t, "alert_source/sync", testRequest)
+	// Act
+	result, err := client.SyncAlertSources(string(id2))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "PagerDuty", result.Name)
+	autopilot.Equals(t, "2026-10-19T12:00:00Z", result.LastManualSyncAlertSources)
+}
+
+func TestServiceGetAlertSources(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query ServiceAlertSourcesList($after:String!$first:Int!$service:ID!){account{service(id: $service){alertSources(after: $after, first: $first){nodes{ {{- template "alert_source_service_request" -}} },{{ template "pagination_request" }},totalCount}}}}`,
+		`{ "after": "", "first": 100, "service": "{{ template "id1_string" }}" }`,
+		`{"data": {"account": {"service": {"alertSources": {"nodes": [{{ template "alert_source_service_1" }}, {{ template "alert_source_service_2" }}], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+
+	client := BestTestClient(t, "alert_source/service_list", testRequest)
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	// Act
+	response, err := service.GetAlertSources(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	alerting := response.WithStatus(ol.AlertSourceStatusTypeEnumAlert)
+	autopilot.Equals(t, 1, len(alerting))
+	autopilot.Equals(t, "Checkout Latency", alerting[0].AlertSource.Name)
+}
+
+func TestServiceReconcileAlertSources(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceAlertSourcesList($after:String!$first:Int!$service:ID!){account{service(id: $service){alertSources(after: $after, first: $first){nodes{ {{- template "alert_source_service_request" -}} },{{ template "pagination_request" }},totalCount}}}}`,
+		`{ "after": "", "first": 100, "service": "{{ template "id1_string" }}" }`,
+		`{"data": {"account": {"service": {"alertSources": {"nodes": [{{ template "alert_source_service_1" }}, {{ template "alert_source_service_2" }}], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation AlertSourceServiceDelete($input:AlertSourceDeleteInput!){alertSourceServiceDelete(input: $input){errors{message,path}}}`,
+		`{"input": { "id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VTZXJ2aWNlLzI" }}`,
+		`{"data": {"alertSourceServiceDelete": {"errors": [] }}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation AlertSourceServiceCreate($input:AlertSourceServiceCreateInput!){alertSourceServiceCreate(input: $input){alertSourceService{ {{- template "alert_source_service_request" -}} },errors{message,path}}}`,
+		`{"input": { "alertSourceExternalIdentifier": { "externalId": "PD-333", "type": "pagerduty" }, "service": { {{ template "id1" }} }}}`,
+		`{"data": {"alertSourceServiceCreate": {"alertSourceService": {"id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VTZXJ2aWNlLzM", "status": "no_data"}, "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "alert_source/service_reconcile", requests...)
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	// Act
+	result, err := service.ReconcileAlertSources(client, []ol.AlertSourceExternalIdentifier{
+		*ol.NewAlertSource(ol.AlertSourceTypeEnumPagerduty, "PD-111"),
+		*ol.NewAlertSource(ol.AlertSourceTypeEnumPagerduty, "PD-333"),
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Deleted))
+	autopilot.Equals(t, "DD-222", result.Deleted[0].AlertSource.ExternalId)
+	autopilot.Equals(t, 1, len(result.Created))
+	autopilot.Equals(t, ol.AlertSourceStatusTypeEnumNoData, result.Created[0].Status)
+}
+
+func TestServiceReconcileAlertSourcesContinuesAfterError(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceAlertSourcesList($after:String!$first:Int!$service:ID!){account{service(id: $service){alertSources(after: $after, first: $first){nodes{ {{- template "alert_source_service_request" -}} },{{ template "pagination_request" }},totalCount}}}}`,
+		`{ "after": "", "first": 100, "service": "{{ template "id1_string" }}" }`,
+		`{"data": {"account": {"service": {"alertSources": {"nodes": [{{ template "alert_source_service_1" }}, {{ template "alert_source_service_2" }}], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation AlertSourceServiceDelete($input:AlertSourceDeleteInput!){alertSourceServiceDelete(input: $input){errors{message,path}}}`,
+		`{"input": { "id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VTZXJ2aWNlLzI" }}`,
+		`{"data": {"alertSourceServiceDelete": {"errors": [{{ template "error1" }}] }}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation AlertSourceServiceCreate($input:AlertSourceServiceCreateInput!){alertSourceServiceCreate(input: $input){alertSourceService{ {{- template "alert_source_service_request" -}} },errors{message,path}}}`,
+		`{"input": { "alertSourceExternalIdentifier": { "externalId": "PD-333", "type": "pagerduty" }, "service": { {{ template "id1" }} }}}`,
+		`{"data": {"alertSourceServiceCreate": {"alertSourceService": {"id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VTZXJ2aWNlLzM", "status": "no_data"}, "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "alert_source/service_reconcile_continues_after_error", requests...)
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	// Act
+	result, err := service.ReconcileAlertSources(client, []ol.AlertSourceExternalIdentifier{
+		*ol.NewAlertSource(ol.AlertSourceTypeEnumPagerduty, "PD-111"),
+		*ol.NewAlertSource(ol.AlertSourceTypeEnumPagerduty, "PD-333"),
+	})
+	// Assert
+	autopilot.Assert(t, err != nil, "expected the failed detach to be returned")
+	autopilot.Equals(t, 0, len(result.Deleted))
+	autopilot.Equals(t, 1, len(result.Created))
+}
+
+func TestServiceReconcileAlertSourcesInvalidServiceId(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "alert_source/service_reconcile_invalid_service_id")
+	service := ol.Service{}
+	// Act
+	_, err := service.ReconcileAlertSources(client, nil)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for a service without an id")
+}
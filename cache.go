@@ -64,6 +64,28 @@ func (cacher *Cacher) TryGetLevel(alias string) (*Level, bool) {
 	return nil, false
 }
 
+func (cacher *Cacher) TryGetCategoryById(id ID) (*Category, bool) {
+	cacher.mutex.Lock()
+	defer cacher.mutex.Unlock()
+	for _, v := range cacher.Categories {
+		if v.Id == id {
+			return &v, true
+		}
+	}
+	return nil, false
+}
+
+func (cacher *Cacher) TryGetLevelById(id ID) (*Level, bool) {
+	cacher.mutex.Lock()
+	defer cacher.mutex.Unlock()
+	for _, v := range cacher.Levels {
+		if v.Id == id {
+			return &v, true
+		}
+	}
+	return nil, false
+}
+
 func (cacher *Cacher) TryGetFilter(alias string) (*Filter, bool) {
 	cacher.mutex.Lock()
 	defer cacher.mutex.Unlock()
@@ -20,7 +20,7 @@ func TestCache(t *testing.T) {
 		`{"data":{"account":{ "lifecycles":[{{ template "lifecycle_1" }}] }}}`,
 	)
 	testRequestThree := autopilot.NewTestRequest(
-		`query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}`,
+		`query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}`,
 		`{ "after": "", "first": 100 }`,
 		`{"data":{"account":{ "teams":{ "nodes":[{{ template "team_1" }}] } }}}`,
 	)
@@ -158,3 +158,23 @@ func TestCache(t *testing.T) {
 	autopilot.Equals(t, false, infraSchema2Ok)
 	autopilot.Equals(t, true, infraSchema2 == nil)
 }
+
+func TestCacheTryGetById(t *testing.T) {
+	// Arrange
+	ol.Cache.Categories["by-id-category"] = ol.Category{Id: id4, Name: "By Id Category"}
+	ol.Cache.Levels["by-id-level"] = ol.Level{Alias: "by-id-level", Id: id4, Name: "By Id Level"}
+	// Act
+	category1, category1Ok := ol.Cache.TryGetCategoryById(id4)
+	category2, category2Ok := ol.Cache.TryGetCategoryById("does_not_exist")
+	level1, level1Ok := ol.Cache.TryGetLevelById(id4)
+	level2, level2Ok := ol.Cache.TryGetLevelById("does_not_exist")
+	// Assert
+	autopilot.Equals(t, true, category1Ok)
+	autopilot.Equals(t, "By Id Category", category1.Name)
+	autopilot.Equals(t, false, category2Ok)
+	autopilot.Equals(t, true, category2 == nil)
+	autopilot.Equals(t, true, level1Ok)
+	autopilot.Equals(t, "by-id-level", level1.Alias)
+	autopilot.Equals(t, false, level2Ok)
+	autopilot.Equals(t, true, level2 == nil)
+}
@@ -0,0 +1,433 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/relvacode/iso8601"
+)
+
+type CampaignId struct {
+	Id   ID     `graphql:"id"`
+	Name string `graphql:"name"`
+}
+
+type CampaignStats struct {
+	Total           int `graphql:"total"`
+	TotalSuccessful int `graphql:"totalSuccessful"`
+}
+
+type CampaignReminder struct {
+	Channels            []CampaignReminderChannelEnum     `graphql:"channels"`
+	DaysOfWeek          []string                          `graphql:"daysOfWeek"`
+	DefaultSlackChannel string                            `graphql:"defaultSlackChannel"`
+	Frequency           int                               `graphql:"frequency"`
+	FrequencyUnit       CampaignReminderFrequencyUnitEnum `graphql:"frequencyUnit"`
+	Message             string                            `graphql:"message"`
+	NextOccurrence      iso8601.Time                      `graphql:"nextOccurrence"`
+	TimeOfDay           string                            `graphql:"timeOfDay"`
+	Timezone            string                            `graphql:"timezone"`
+}
+
+type Campaign struct {
+	CampaignId
+
+	CheckStats   CampaignStats      `graphql:"checkStats"`
+	EndedDate    iso8601.Time       `graphql:"endedDate"`
+	Filter       FilterId           `graphql:"filter"`
+	HtmlUrl      string             `graphql:"htmlUrl"`
+	Owner        TeamId             `graphql:"owner"`
+	ProjectBrief string             `graphql:"projectBrief: rawProjectBrief"`
+	Reminder     *CampaignReminder  `graphql:"reminder"`
+	ServiceStats CampaignStats      `graphql:"serviceStats"`
+	StartDate    iso8601.Time       `graphql:"startDate"`
+	Status       CampaignStatusEnum `graphql:"status"`
+	TargetDate   iso8601.Time       `graphql:"targetDate"`
+}
+
+type CampaignConnection struct {
+	Nodes      []Campaign
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type CampaignService struct {
+	Service ServiceId                 `graphql:"service"`
+	Status  CampaignServiceStatusEnum `graphql:"status"`
+}
+
+type CampaignServiceConnection struct {
+	Nodes      []CampaignService
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+// PromotedCheck is a campaign check that was moved into the rubric, with the category and level it now belongs to
+type PromotedCheck struct {
+	Id       ID       `graphql:"id"`
+	Name     string   `graphql:"name"`
+	Category Category `graphql:"category"`
+	Level    Level    `graphql:"level"`
+}
+
+type CampaignEndResult struct {
+	Campaign       Campaign
+	PromotedChecks []PromotedCheck
+}
+
+// CampaignCreateInput specifies the input fields used to create a campaign.
+type CampaignCreateInput struct {
+	Name         string  `json:"name" yaml:"name" example:"example_name"`                                                // The name of the campaign. (Required.)
+	OwnerId      ID      `json:"ownerId" yaml:"ownerId" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"`                       // The id of the team that owns the campaign. (Required.)
+	FilterId     *ID     `json:"filterId,omitempty" yaml:"filterId,omitempty" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"` // The id of the filter applied to the campaign. (Optional.)
+	ProjectBrief *string `json:"projectBrief,omitempty" yaml:"projectBrief,omitempty" example:"example_brief"`           // The project brief of the campaign. (Optional.)
+}
+
+// CampaignUpdateInput specifies the input fields used to update a campaign.
+type CampaignUpdateInput struct {
+	Id           ID                     `json:"id" yaml:"id" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"`                                 // The id of the campaign to be updated. (Required.)
+	Name         *string                `json:"name,omitempty" yaml:"name,omitempty" example:"example_name"`                            // The name of the campaign. (Optional.)
+	OwnerId      *ID                    `json:"ownerId,omitempty" yaml:"ownerId,omitempty" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"`   // The id of the team that owns the campaign. (Optional.)
+	FilterId     *ID                    `json:"filterId,omitempty" yaml:"filterId,omitempty" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"` // The id of the filter applied to the campaign. (Optional.)
+	ProjectBrief *string                `json:"projectBrief,omitempty" yaml:"projectBrief,omitempty" example:"example_brief"`           // The project brief of the campaign. (Optional.)
+	Reminder     *CampaignReminderInput `json:"reminder,omitempty" yaml:"reminder,omitempty"`                                           // The reminder settings of the campaign. (Optional.)
+}
+
+// CampaignReminderInput specifies the input fields used to configure the reminders of a campaign.
+type CampaignReminderInput struct {
+	Channels            []CampaignReminderChannelEnum     `json:"channels" yaml:"channels" example:"['slack', 'email']"`                                   // The communication channels through which reminders are delivered. (Required.)
+	DaysOfWeek          *[]string                         `json:"daysOfWeek,omitempty" yaml:"daysOfWeek,omitempty" example:"['monday', 'thursday']"`       // The days of the week on which reminders are delivered, when the frequency unit is week. (Optional.)
+	DefaultSlackChannel *string                           `json:"defaultSlackChannel,omitempty" yaml:"defaultSlackChannel,omitempty" example:"#campaigns"` // The slack channel used for teams without a slack contact. (Optional.)
+	Frequency           int                               `json:"frequency" yaml:"frequency" example:"1"`                                                  // How often reminders are delivered, in frequency units. (Required.)
+	FrequencyUnit       CampaignReminderFrequencyUnitEnum `json:"frequencyUnit" yaml:"frequencyUnit" example:"week"`                                       // The time unit of the reminder frequency. (Required.)
+	Message             *string                           `json:"message,omitempty" yaml:"message,omitempty" example:"example_message"`                    // A custom message included in the reminders. (Optional.)
+	TimeOfDay           string                            `json:"timeOfDay" yaml:"timeOfDay" example:"09:00"`                                              // The time of day at which reminders are delivered. (Required.)
+	Timezone            string                            `json:"timezone" yaml:"timezone" example:"America/Toronto"`                                      // The timezone used for the time of day. (Required.)
+}
+
+// CampaignScheduleUpdateInput specifies the input fields used to schedule a campaign.
+type CampaignScheduleUpdateInput struct {
+	Id         ID           `json:"id" yaml:"id" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"`          // The id of the campaign to be scheduled. (Required.)
+	StartDate  iso8601.Time `json:"startDate" yaml:"startDate" example:"2024-01-05T01:00:00.000Z"`   // The date the campaign will start. (Required.)
+	TargetDate iso8601.Time `json:"targetDate" yaml:"targetDate" example:"2024-01-05T01:00:00.000Z"` // The date the campaign is expected to end. (Required.)
+}
+
+// CampaignUnscheduleInput specifies the input fields used to unschedule a campaign.
+type CampaignUnscheduleInput struct {
+	Id ID `json:"id" yaml:"id" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"` // The id of the campaign to be unscheduled. (Required.)
+}
+
+// CampaignEndInput specifies the input fields used to end a campaign and promote its checks to the rubric.
+type CampaignEndInput struct {
+	Id              ID                     `json:"id" yaml:"id" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"`     // The id of the campaign to be ended. (Required.)
+	ChecksToPromote *[]CheckToPromoteInput `json:"checksToPromote,omitempty" yaml:"checksToPromote,omitempty"` // The list of campaign checks to be promoted to the rubric. (Optional.)
+}
+
+// CampaignSendReminderInput specifies the input fields used to send a reminder to the owners of failing services.
+type CampaignSendReminderInput struct {
+	Id            ID                         `json:"id" yaml:"id" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"`                           // The id of the campaign. (Required.)
+	ReminderTypes []CampaignReminderTypeEnum `json:"reminderTypes" yaml:"reminderTypes" example:"['email', 'slack']"`                  // How the reminder will be sent. (Required.)
+	CustomMessage *string                    `json:"customMessage,omitempty" yaml:"customMessage,omitempty" example:"example_message"` // A custom message included in the reminder. (Optional.)
+}
+
+// CampaignFilterInput specifies the input fields used to filter campaigns.
+type CampaignFilterInput struct {
+	Key  CampaignFilterEnum `json:"key" yaml:"key" example:"status"`                               // Field to be filtered. (Required.)
+	Arg  *string            `json:"arg,omitempty" yaml:"arg,omitempty" example:"example_arg"`      // Value to be filtered. (Optional.)
+	Type *BasicTypeEnum     `json:"type,omitempty" yaml:"type,omitempty" example:"does_not_equal"` // The operation applied to value on the field. (Optional.)
+}
+
+func (campaign *Campaign) ListServices(client *Client, variables *PayloadVariables) (*CampaignServiceConnection, error) {
+	var q struct {
+		Account struct {
+			Campaign struct {
+				Services CampaignServiceConnection `graphql:"services(after: $after, first: $first)"`
+			} `graphql:"campaign(id: $campaign)"`
+		}
+	}
+	if campaign.Id == "" {
+		return nil, fmt.Errorf("unable to get Services, invalid campaign id: '%s'", campaign.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["campaign"] = campaign.Id
+	if err := client.Query(&q, *variables, WithName("CampaignServicesList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Campaign.Services.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Campaign.Services.PageInfo.End
+		resp, err := campaign.ListServices(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Campaign.Services.Nodes = append(q.Account.Campaign.Services.Nodes, resp.Nodes...)
+		q.Account.Campaign.Services.PageInfo = resp.PageInfo
+		q.Account.Campaign.Services.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Campaign.Services, nil
+}
+
+func (client *Client) CreateCampaign(input CampaignCreateInput) (*Campaign, error) {
+	var m struct {
+		Payload struct {
+			Campaign Campaign
+			Errors   []OpsLevelErrors
+		} `graphql:"campaignCreate(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("CampaignCreate"))
+	return &m.Payload.Campaign, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) GetCampaign(id ID) (*Campaign, error) {
+	var q struct {
+		Account struct {
+			Campaign Campaign `graphql:"campaign(id: $id)"`
+		}
+	}
+	v := PayloadVariables{
+		"id": id,
+	}
+	err := client.Query(&q, v, WithName("CampaignGet"))
+	if q.Account.Campaign.Id == "" {
+		err = fmt.Errorf("Campaign with ID '%s' not found", id)
+	}
+	return &q.Account.Campaign, HandleErrors(err, nil)
+}
+
+func (client *Client) ListCampaigns(variables *PayloadVariables) (*CampaignConnection, error) {
+	var q struct {
+		Account struct {
+			Campaigns CampaignConnection `graphql:"campaigns(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("CampaignList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Campaigns.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Campaigns.PageInfo.End
+		resp, err := client.ListCampaigns(variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Campaigns.Nodes = append(q.Account.Campaigns.Nodes, resp.Nodes...)
+		q.Account.Campaigns.PageInfo = resp.PageInfo
+		q.Account.Campaigns.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Campaigns, nil
+}
+
+func (client *Client) ListCampaignsWithFilter(filter []CampaignFilterInput, variables *PayloadVariables) (*CampaignConnection, error) {
+	var q struct {
+		Account struct {
+			Campaigns CampaignConnection `graphql:"campaigns(filter: $filter, after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["filter"] = filter
+	if err := client.Query(&q, *variables, WithName("CampaignListWithFilter")); err != nil {
+		return nil, err
+	}
+	for q.Account.Campaigns.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Campaigns.PageInfo.End
+		resp, err := client.ListCampaignsWithFilter(filter, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Campaigns.Nodes = append(q.Account.Campaigns.Nodes, resp.Nodes...)
+		q.Account.Campaigns.PageInfo = resp.PageInfo
+		q.Account.Campaigns.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Campaigns, nil
+}
+
+func (client *Client) ListCampaignsWithSort(sortBy CampaignSortEnum, variables *PayloadVariables) (*CampaignConnection, error) {
+	var q struct {
+		Account struct {
+			Campaigns CampaignConnection `graphql:"campaigns(sortBy: $sortBy, after: $after, first: $first)"`
+		}
+	}
+	if !slices.Contains(AllCampaignSortEnum, string(sortBy)) {
+		return nil, fmt.Errorf("sortBy must be one of %v. Given: '%s'", AllCampaignSortEnum, sortBy)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["sortBy"] = sortBy
+	if err := client.Query(&q, *variables, WithName("CampaignListWithSort")); err != nil {
+		return nil, err
+	}
+	for q.Account.Campaigns.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Campaigns.PageInfo.End
+		resp, err := client.ListCampaignsWithSort(sortBy, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Campaigns.Nodes = append(q.Account.Campaigns.Nodes, resp.Nodes...)
+		q.Account.Campaigns.PageInfo = resp.PageInfo
+		q.Account.Campaigns.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Campaigns, nil
+}
+
+func (client *Client) UpdateCampaign(input CampaignUpdateInput) (*Campaign, error) {
+	var m struct {
+		Payload struct {
+			Campaign Campaign
+			Errors   []OpsLevelErrors
+		} `graphql:"campaignUpdate(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("CampaignUpdate"))
+	return &m.Payload.Campaign, HandleErrors(err, m.Payload.Errors)
+}
+
+// UpdateCampaignReminder replaces the reminder settings of a campaign
+func (client *Client) UpdateCampaignReminder(id ID, input CampaignReminderInput) (*Campaign, error) {
+	return client.UpdateCampaign(CampaignUpdateInput{
+		Id:       id,
+		Reminder: &input,
+	})
+}
+
+func (client *Client) DeleteCampaign(id ID) error {
+	var m struct {
+		Payload struct {
+			Id     ID               `graphql:"deletedCampaignId"`
+			Errors []OpsLevelErrors `graphql:"errors"`
+		} `graphql:"campaignDelete(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": DeleteInput{Id: id},
+	}
+	err := client.Mutate(&m, v, WithName("CampaignDelete"))
+	return HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) ScheduleCampaign(input CampaignScheduleUpdateInput) (*Campaign, error) {
+	var m struct {
+		Payload struct {
+			Campaign Campaign
+			Errors   []OpsLevelErrors
+		} `graphql:"campaignScheduleUpdate(input: $input)"`
+	}
+	if input.TargetDate.Before(input.StartDate.Time) {
+		return nil, fmt.Errorf("campaign target date '%s' must not be before its start date '%s'", input.TargetDate, input.StartDate)
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("CampaignScheduleUpdate"))
+	return &m.Payload.Campaign, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) UnscheduleCampaign(id ID) (*Campaign, error) {
+	var m struct {
+		Payload struct {
+			Campaign Campaign
+			Errors   []OpsLevelErrors
+		} `graphql:"campaignUnschedule(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": CampaignUnscheduleInput{Id: id},
+	}
+	err := client.Mutate(&m, v, WithName("CampaignUnschedule"))
+	return &m.Payload.Campaign, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) SendCampaignReminder(input CampaignSendReminderInput) error {
+	var m struct {
+		Payload struct {
+			Errors []OpsLevelErrors
+		} `graphql:"campaignSendReminder(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("CampaignSendReminder"))
+	return HandleErrors(err, m.Payload.Errors)
+}
+
+// CopyChecksToCampaign copies existing rubric checks into a campaign
+func (client *Client) CopyChecksToCampaign(input ChecksCopyToCampaignInput) (*Campaign, error) {
+	var m struct {
+		Payload struct {
+			Campaign Campaign
+			Errors   []OpsLevelErrors
+		} `graphql:"checksCopyToCampaign(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("ChecksCopyToCampaign"))
+	return &m.Payload.Campaign, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) EndCampaign(input CampaignEndInput) (*CampaignEndResult, error) {
+	var m struct {
+		Payload struct {
+			Campaign       Campaign
+			PromotedChecks []PromotedCheck
+			Errors         []OpsLevelErrors
+		} `graphql:"campaignEnd(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("CampaignEnd"))
+	result := CampaignEndResult{
+		Campaign:       m.Payload.Campaign,
+		PromotedChecks: m.Payload.PromotedChecks,
+	}
+	return &result, HandleErrors(err, m.Payload.Errors)
+}
+
+// PromoteChecks ends a campaign and promotes the given campaign checks into the rubric
+//
+// The target category and level of every check must exist in the Cache, which is refreshed once on a miss
+func (client *Client) PromoteChecks(campaignId ID, checks []CheckToPromoteInput) (*CampaignEndResult, error) {
+	if len(checks) == 0 {
+		return nil, fmt.Errorf("no checks to promote for campaign '%s'", campaignId)
+	}
+	if err := client.validateChecksToPromote(checks); err != nil {
+		return nil, err
+	}
+	return client.EndCampaign(CampaignEndInput{
+		Id:              campaignId,
+		ChecksToPromote: &checks,
+	})
+}
+
+func (client *Client) validateChecksToPromote(checks []CheckToPromoteInput) error {
+	var allErrors error
+	categoriesRefreshed, levelsRefreshed := false, false
+	for _, check := range checks {
+		if _, ok := Cache.TryGetCategoryById(check.CategoryId); !ok && !categoriesRefreshed {
+			Cache.CacheCategories(client)
+			categoriesRefreshed = true
+		}
+		if _, ok := Cache.TryGetCategoryById(check.CategoryId); !ok {
+			allErrors = errors.Join(allErrors, fmt.Errorf("cannot promote check '%s', category '%s' not found", check.CheckId, check.CategoryId))
+		}
+
+		if _, ok := Cache.TryGetLevelById(check.LevelId); !ok && !levelsRefreshed {
+			Cache.CacheLevels(client)
+			levelsRefreshed = true
+		}
+		if _, ok := Cache.TryGetLevelById(check.LevelId); !ok {
+			allErrors = errors.Join(allErrors, fmt.Errorf("cannot promote check '%s', level '%s' not found", check.CheckId, check.LevelId))
+		}
+	}
+	return allErrors
+}
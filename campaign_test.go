@@ -0,0 +1,324 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestCreateCampaign(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation CampaignCreate($input:CampaignCreateInput!){campaignCreate(input: $input){campaign{ {{- template "campaign_request" -}} },errors{message,path}}}`,
+		`{"input": { "name": "Upgrade to Go 1.22", "ownerId": "{{ template "id3_string" }}", "filterId": "{{ template "id2_string" }}" }}`,
+		`{"data": { "campaignCreate": { "campaign": {{ template "campaign_1" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/create", testRequest)
+	// Act
+	result, err := client.CreateCampaign(ol.CampaignCreateInput{
+		Name:     "Upgrade to Go 1.22",
+		OwnerId:  id3,
+		FilterId: &id2,
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id1, result.Id)
+	autopilot.Equals(t, "platform", result.Owner.Alias)
+	autopilot.Equals(t, "Go Services", result.Filter.Name)
+	autopilot.Equals(t, ol.CampaignStatusEnumInProgress, result.Status)
+	autopilot.Equals(t, 4, result.ServiceStats.TotalSuccessful)
+}
+
+func TestGetCampaign(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query CampaignGet($id:ID!){account{campaign(id: $id){ {{- template "campaign_request" -}} }}}`,
+		`{"id": "{{ template "id2_string" }}"}`,
+		`{"data": {"account": {"campaign": {{ template "campaign_2" }} }}}`,
+	)
+	client := BestTestClient(t, "campaign/get", testRequest)
+	// Act
+	result, err := client.GetCampaign(id2)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Adopt OpenTelemetry", result.Name)
+	autopilot.Equals(t, ol.CampaignStatusEnumDraft, result.Status)
+	autopilot.Equals(t, ol.CampaignReminderFrequencyUnitEnumWeek, result.Reminder.FrequencyUnit)
+	autopilot.Equals(t, []ol.CampaignReminderChannelEnum{ol.CampaignReminderChannelEnumSlack, ol.CampaignReminderChannelEnumEmail}, result.Reminder.Channels)
+	autopilot.Assert(t, result.StartDate.IsZero(), "expected unscheduled campaign to have no start date")
+}
+
+func TestGetMissingCampaign(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query CampaignGet($id:ID!){account{campaign(id: $id){ {{- template "campaign_request" -}} }}}`,
+		`{"id": "{{ template "id1_string" }}"}`,
+		`{"data": {"account": {"campaign": null }}}`,
+	)
+	client := BestTestClient(t, "campaign/get_missing", testRequest)
+	// Act
+	_, err := client.GetCampaign(id1)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for a missing campaign")
+}
+
+func TestListCampaigns(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query CampaignList($after:String!$first:Int!){account{campaigns(after: $after, first: $first){nodes{ {{- template "campaign_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "campaigns": { "nodes": [ {{ template "campaign_1" }}, {{ template "campaign_2" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 2 }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query CampaignList($after:String!$first:Int!){account{campaigns(after: $after, first: $first){nodes{ {{- template "campaign_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_second_query_variables" }}`,
+		`{ "data": { "account": { "campaigns": { "nodes": [ {{ template "campaign_3" }} ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "campaign/list", requests...)
+	// Act
+	response, err := client.ListCampaigns(nil)
+	result := response.Nodes
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, response.TotalCount)
+	autopilot.Equals(t, "Upgrade to Go 1.22", result[0].Name)
+	autopilot.Equals(t, ol.CampaignStatusEnumEnded, result[2].Status)
+}
+
+func TestListCampaignsWithSort(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query CampaignListWithSort($after:String!$first:Int!$sortBy:CampaignSortEnum!){account{campaigns(sortBy: $sortBy, after: $after, first: $first){nodes{ {{- template "campaign_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{ {{ template "first_page_variables" }}, "sortBy": "target_date_ASC" }`,
+		`{ "data": { "account": { "campaigns": { "nodes": [ {{ template "campaign_2" }}, {{ template "campaign_1" }} ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+
+	client := BestTestClient(t, "campaign/list_with_sort", testRequest)
+	// Act
+	response, err := client.ListCampaignsWithSort(ol.CampaignSortEnumTargetDateAsc, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, "Upgrade to Go 1.22", response.Nodes[1].Name)
+}
+
+func TestListCampaignsWithFilter(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query CampaignListWithFilter($after:String!$filter:[CampaignFilterInput!]!$first:Int!){account{campaigns(filter: $filter, after: $after, first: $first){nodes{ {{- template "campaign_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{ {{ template "first_page_variables" }}, "filter": [ { "key": "status", "arg": "ended" } ] }`,
+		`{ "data": { "account": { "campaigns": { "nodes": [ {{ template "campaign_3" }} ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+	client := BestTestClient(t, "campaign/list_with_filter", testRequest)
+	// Act
+	response, err := client.ListCampaignsWithFilter([]ol.CampaignFilterInput{
+		{Key: ol.CampaignFilterEnumStatus, Arg: ol.RefOf(string(ol.CampaignStatusEnumEnded))},
+	}, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, response.TotalCount)
+	autopilot.Equals(t, "Retire Python 2", response.Nodes[0].Name)
+}
+
+func TestUpdateCampaignReminder(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation CampaignUpdate($input:CampaignUpdateInput!){campaignUpdate(input: $input){campaign{ {{- template "campaign_request" -}} },errors{message,path}}}`,
+		`{"input": { "id": "{{ template "id2_string" }}", "reminder": { "channels": ["slack", "email"], "daysOfWeek": ["monday"], "defaultSlackChannel": "#campaigns", "frequency": 1, "frequencyUnit": "week", "timeOfDay": "09:00", "timezone": "America/Toronto" } }}`,
+		`{"data": { "campaignUpdate": { "campaign": {{ template "campaign_2" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/update_reminder", testRequest)
+	// Act
+	result, err := client.UpdateCampaignReminder(id2, ol.CampaignReminderInput{
+		Channels:            []ol.CampaignReminderChannelEnum{ol.CampaignReminderChannelEnumSlack, ol.CampaignReminderChannelEnumEmail},
+		DaysOfWeek:          &[]string{"monday"},
+		DefaultSlackChannel: ol.RefOf("#campaigns"),
+		Frequency:           1,
+		FrequencyUnit:       ol.CampaignReminderFrequencyUnitEnumWeek,
+		TimeOfDay:           "09:00",
+		Timezone:            "America/Toronto",
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "#campaigns", result.Reminder.DefaultSlackChannel)
+}
+
+func TestDeleteCampaign(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation CampaignDelete($input:DeleteInput!){campaignDelete(input: $input){deletedCampaignId,errors{message,path}}}`,
+		`{"input": { {{ template "id1" }} }}`,
+		`{"data": { "campaignDelete": { "deletedCampaignId": "{{ template "id1_string" }}", "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/delete", testRequest)
+	// Act
+	err := client.DeleteCampaign(id1)
+	// Assert
+	autopilot.Ok(t, err)
+}
+
+func TestScheduleCampaign(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation CampaignScheduleUpdate($input:CampaignScheduleUpdateInput!){campaignScheduleUpdate(input: $input){campaign{ {{- template "campaign_request" -}} },errors{message,path}}}`,
+		`{"input": { {{ template "id1" }}, "startDate": "2024-08-01T00:00:00Z", "targetDate": "2024-09-01T00:00:00Z" }}`,
+		`{"data": { "campaignScheduleUpdate": { "campaign": {{ template "campaign_1" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/schedule", testRequest)
+	// Act
+	result, err := client.ScheduleCampaign(ol.CampaignScheduleUpdateInput{
+		Id:         id1,
+		StartDate:  ol.NewISO8601Date("2024-08-01T00:00:00Z"),
+		TargetDate: ol.NewISO8601Date("2024-09-01T00:00:00Z"),
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, ol.NewISO8601Date("2024-09-01T00:00:00Z").Unix(), result.TargetDate.Unix())
+}
+
+func TestScheduleCampaignTargetBeforeStart(t *testing.T) {
+	// Arrange
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetMaxRetries(0))
+	// Act
+	_, err := client.ScheduleCampaign(ol.CampaignScheduleUpdateInput{
+		Id:         id1,
+		StartDate:  ol.NewISO8601Date("2024-09-01T00:00:00Z"),
+		TargetDate: ol.NewISO8601Date("2024-08-01T00:00:00Z"),
+	})
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error when the target date precedes the start date")
+}
+
+func TestUnscheduleCampaign(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation CampaignUnschedule($input:CampaignUnscheduleInput!){campaignUnschedule(input: $input){campaign{ {{- template "campaign_request" -}} },errors{message,path}}}`,
+		`{"input": { {{ template "id2" }} }}`,
+		`{"data": { "campaignUnschedule": { "campaign": {{ template "campaign_2" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/unschedule", testRequest)
+	// Act
+	result, err := client.UnscheduleCampaign(id2)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, ol.CampaignStatusEnumDraft, result.Status)
+}
+
+func TestSendCampaignReminder(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation CampaignSendReminder($input:CampaignSendReminderInput!){campaignSendReminder(input: $input){errors{message,path}}}`,
+		`{"input": { {{ template "id1" }}, "reminderTypes": ["slack"], "customMessage": "One week left!" }}`,
+		`{"data": { "campaignSendReminder": { "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/send_reminder", testRequest)
+	// Act
+	err := client.SendCampaignReminder(ol.CampaignSendReminderInput{
+		Id:            id1,
+		ReminderTypes: []ol.CampaignReminderTypeEnum{ol.CampaignReminderTypeEnumSlack},
+		CustomMessage: ol.RefOf("One week left!"),
+	})
+	// Assert
+	autopilot.Ok(t, err)
+}
+
+func TestCopyChecksToCampaign(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation ChecksCopyToCampaign($input:ChecksCopyToCampaignInput!){checksCopyToCampaign(input: $input){campaign{ {{- template "campaign_request" -}} },errors{message,path}}}`,
+		`{"input": { "campaignId": "{{ template "id1_string" }}", "checkIds": [ "{{ template "id2_string" }}", "{{ template "id3_string" }}" ] }}`,
+		`{"data": { "checksCopyToCampaign": { "campaign": {{ template "campaign_1" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/copy_checks", testRequest)
+	// Act
+	result, err := client.CopyChecksToCampaign(ol.ChecksCopyToCampaignInput{
+		CampaignId: id1,
+		CheckIds:   []string{string(id2), string(id3)},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, result.CheckStats.Total)
+}
+
+func TestCampaignListServices(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query CampaignServicesList($after:String!$campaign:ID!$first:Int!){account{campaign(id: $campaign){services(after: $after, first: $first){nodes{service{id,aliases},status},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "campaign": "{{ template "id1_string" }}" }`,
+		`{ "data": { "account": { "campaign": { "services": { "nodes": [ { "service": { {{ template "id2" }}, "aliases": ["api"] }, "status": "passing" } ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query CampaignServicesList($after:String!$campaign:ID!$first:Int!){account{campaign(id: $campaign){services(after: $after, first: $first){nodes{service{id,aliases},status},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "second_page_variables" }}, "campaign": "{{ template "id1_string" }}" }`,
+		`{ "data": { "account": { "campaign": { "services": { "nodes": [ { "service": { {{ template "id3" }}, "aliases": ["web"] }, "status": "failing" } ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "campaign/services", requests...)
+	campaign := ol.Campaign{
+		CampaignId: ol.CampaignId{
+			Id: id1,
+		},
+	}
+	// Act
+	response, err := campaign.ListServices(client, nil)
+	result := response.Nodes
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, ol.CampaignServiceStatusEnumPassing, result[0].Status)
+	autopilot.Equals(t, "web", result[1].Service.Aliases[0])
+	autopilot.Equals(t, ol.CampaignServiceStatusEnumFailing, result[1].Status)
+}
+
+func TestPromoteChecks(t *testing.T) {
+	// Arrange
+	ol.Cache.Categories["promotion-category"] = ol.Category{Id: id2, Name: "Promotion Category"}
+	ol.Cache.Levels["promotion-level"] = ol.Level{Alias: "promotion-level", Id: id3, Index: 2, Name: "Promotion Level"}
+	testRequest := autopilot.NewTestRequest(
+		`mutation CampaignEnd($input:CampaignEndInput!){campaignEnd(input: $input){campaign{ {{- template "campaign_request" -}} },promotedChecks{id,name,category{id,name},level{alias,description,id,index,name}},errors{message,path}}}`,
+		`{"input": { {{ template "id1" }}, "checksToPromote": [ { "checkId": "{{ template "id4_string" }}", "categoryId": "{{ template "id2_string" }}", "levelId": "{{ template "id3_string" }}" } ] }}`,
+		`{"data": { "campaignEnd": { "campaign": {{ template "campaign_3" }}, "promotedChecks": [ { {{ template "id4" }}, "name": "Uses Go 1.22", "category": {{ template "category_2" }}, "level": {{ template "level_3" }} } ], "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "campaign/promote_checks", testRequest)
+	// Act
+	result, err := client.PromoteChecks(id1, []ol.CheckToPromoteInput{
+		{CheckId: id4, CategoryId: id2, LevelId: id3},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, ol.CampaignStatusEnumEnded, result.Campaign.Status)
+	autopilot.Equals(t, 1, len(result.PromotedChecks))
+	autopilot.Equals(t, id4, result.PromotedChecks[0].Id)
+	autopilot.Equals(t, id2, result.PromotedChecks[0].Category.Id)
+	autopilot.Equals(t, 3, result.PromotedChecks[0].Level.Index)
+}
+
+func TestPromoteChecksUnknownLevel(t *testing.T) {
+	// Arrange
+	ol.Cache.Categories["promotion-category"] = ol.Category{Id: id2, Name: "Promotion Category"}
+	testRequest := autopilot.NewTestRequest(
+		`{account{rubric{levels{nodes{alias,description,id,index,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{}`,
+		`{"data":{"account":{"rubric":{ "levels":{ "nodes":[ {{ template "level_1" }} ] } }}}}`,
+	)
+	client := BestTestClient(t, "campaign/promote_checks_unknown_level", testRequest)
+	// Act
+	_, err := client.PromoteChecks(id1, []ol.CheckToPromoteInput{
+		{CheckId: id4, CategoryId: id2, LevelId: "Z2lkOi8vb3BzbGV2ZWwvTGV2ZWwvOTk5"},
+	})
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error when promoting to an unknown level")
+}
+
+func TestPromoteChecksRequiresChecks(t *testing.T) {
+	// Arrange
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetMaxRetries(0))
+	// Act
+	_, err := client.PromoteChecks(id1, nil)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error when there are no checks to promote")
+}
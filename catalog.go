@@ -0,0 +1,966 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogSpec is the desired state of an account's catalog, usually loaded from YAML with LoadCatalogSpec.
+//
+// Resources are matched to the live account by alias. Empty fields are left unmanaged and
+// a nil section, ex: no `systems` key, leaves that whole kind of resource unmanaged.
+type CatalogSpec struct {
+	Version  int                  `json:"version" yaml:"version"`
+	Domains  []CatalogDomainSpec  `json:"domains,omitempty" yaml:"domains,omitempty"`
+	Systems  []CatalogSystemSpec  `json:"systems,omitempty" yaml:"systems,omitempty"`
+	Teams    []CatalogTeamSpec    `json:"teams,omitempty" yaml:"teams,omitempty"`
+	Services []CatalogServiceSpec `json:"services,omitempty" yaml:"services,omitempty"`
+}
+
+type CatalogDomainSpec struct {
+	Alias       string `json:"alias" yaml:"alias"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner       string `json:"owner,omitempty" yaml:"owner,omitempty"` // The alias of the owning team
+	Note        string `json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+type CatalogSystemSpec struct {
+	Alias       string `json:"alias" yaml:"alias"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner       string `json:"owner,omitempty" yaml:"owner,omitempty"`   // The alias of the owning team
+	Domain      string `json:"domain,omitempty" yaml:"domain,omitempty"` // The alias of the parent domain
+	Note        string `json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+type CatalogTeamSpec struct {
+	Alias            string `json:"alias" yaml:"alias"`
+	Name             string `json:"name" yaml:"name"`
+	Responsibilities string `json:"responsibilities,omitempty" yaml:"responsibilities,omitempty"`
+	Parent           string `json:"parent,omitempty" yaml:"parent,omitempty"` // The alias of the parent team
+}
+
+type CatalogServiceSpec struct {
+	Alias       string     `json:"alias" yaml:"alias"`
+	Name        string     `json:"name" yaml:"name"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner       string     `json:"owner,omitempty" yaml:"owner,omitempty"`   // The alias of the owning team
+	System      string     `json:"system,omitempty" yaml:"system,omitempty"` // The alias of the parent system
+	Tier        string     `json:"tier,omitempty" yaml:"tier,omitempty"`
+	Lifecycle   string     `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	Language    string     `json:"language,omitempty" yaml:"language,omitempty"`
+	Framework   string     `json:"framework,omitempty" yaml:"framework,omitempty"`
+	Product     string     `json:"product,omitempty" yaml:"product,omitempty"`
+	Tags        []TagInput `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Tools       []ToolSpec `json:"tools,omitempty" yaml:"tools,omitempty"`
+}
+
+type CatalogAction string
+
+const (
+	CatalogActionCreate CatalogAction = "create"
+	CatalogActionUpdate CatalogAction = "update"
+	CatalogActionDelete CatalogAction = "delete"
+)
+
+type CatalogResourceKind string
+
+const (
+	CatalogResourceKindDomain  CatalogResourceKind = "domain"
+	CatalogResourceKindSystem  CatalogResourceKind = "system"
+	CatalogResourceKindTeam    CatalogResourceKind = "team"
+	CatalogResourceKindService CatalogResourceKind = "service"
+	CatalogResourceKindTag     CatalogResourceKind = "tag"
+	CatalogResourceKindTool    CatalogResourceKind = "tool"
+)
+
+// catalogApplyOrder is the order resources are created and updated in, deletes run in reverse
+var catalogApplyOrder = []CatalogResourceKind{
+	CatalogResourceKindDomain,
+	CatalogResourceKindSystem,
+	CatalogResourceKindTeam,
+	CatalogResourceKindService,
+	CatalogResourceKindTag,
+	CatalogResourceKindTool,
+}
+
+type CatalogFieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// CatalogChange is a single create, update or delete of a resource
+type CatalogChange struct {
+	Action  CatalogAction        `json:"action"`
+	Kind    CatalogResourceKind  `json:"kind"`
+	Alias   string               `json:"alias"`             // Tags use "key:value" and tools "category/displayName/environment"
+	Service string               `json:"service,omitempty"` // The service alias of a tag or tool
+	Fields  []CatalogFieldChange `json:"fields,omitempty"`
+
+	apply func(applier *catalogApplier) error
+}
+
+// CatalogPlan lists the changes needed to make the account match a CatalogSpec, in the order they are applied
+type CatalogPlan struct {
+	Changes []CatalogChange `json:"changes"`
+
+	teams map[string]ID // Team alias to id of the live teams when planned
+}
+
+type CatalogOptions struct {
+	Prune bool // Delete resources, tags and tools that are not in the spec
+}
+
+// LoadCatalogSpec parses a YAML or JSON catalog document
+func LoadCatalogSpec(data []byte) (*CatalogSpec, error) {
+	output := &CatalogSpec{}
+	if err := yaml.Unmarshal(data, output); err != nil {
+		return nil, fmt.Errorf("unable to parse catalog spec: %w", err)
+	}
+	if err := output.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid catalog spec: %w", err)
+	}
+	return output, nil
+}
+
+func ReadCatalogSpec(path string) (*CatalogSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadCatalogSpec(data)
+}
+
+// Validate checks every resource has a unique alias and a name
+func (spec *CatalogSpec) Validate() error {
+	var errs []error
+	if spec.Version != 1 {
+		errs = append(errs, fmt.Errorf("version must be 1. Given: '%d'", spec.Version))
+	}
+	check := func(kind CatalogResourceKind, aliases []string, names []string) {
+		seen := map[string]bool{}
+		for i, alias := range aliases {
+			switch {
+			case alias == "":
+				errs = append(errs, fmt.Errorf("%s %d is missing an alias", kind, i))
+			case seen[alias]:
+				errs = append(errs, fmt.Errorf("%s '%s' is defined more than once", kind, alias))
+			case names[i] == "":
+				errs = append(errs, fmt.Errorf("%s '%s' is missing a name", kind, alias))
+			}
+			seen[alias] = true
+		}
+	}
+	var aliases, names []string
+	for _, domain := range spec.Domains {
+		aliases, names = append(aliases, domain.Alias), append(names, domain.Name)
+	}
+	check(CatalogResourceKindDomain, aliases, names)
+	aliases, names = nil, nil
+	for _, system := range spec.Systems {
+		aliases, names = append(aliases, system.Alias), append(names, system.Name)
+	}
+	check(CatalogResourceKindSystem, aliases, names)
+	aliases, names = nil, nil
+	for _, team := range spec.Teams {
+		aliases, names = append(aliases, team.Alias), append(names, team.Name)
+	}
+	check(CatalogResourceKindTeam, aliases, names)
+	aliases, names = nil, nil
+	for _, service := range spec.Services {
+		aliases, names = append(aliases, service.Alias), append(names, service.Name)
+		for _, tag := range service.Tags {
+			if err := ValidateTagKey(tag.Key); err != nil {
+				errs = append(errs, fmt.Errorf("service '%s': %w", service.Alias, err))
+			}
+		}
+		for _, tool := range service.Tools {
+			if !slices.Contains(AllToolCategory, string(tool.Category)) {
+				errs = append(errs, fmt.Errorf("service '%s' tool '%s' category must be one of %v. Given: '%s'", service.Alias, tool.DisplayName, AllToolCategory, tool.Category))
+			}
+		}
+	}
+	check(CatalogResourceKindService, aliases, names)
+	return errors.Join(errs...)
+}
+
+func (plan *CatalogPlan) IsEmpty() bool {
+	return len(plan.Changes) == 0
+}
+
+// Count returns the number of changes with the given action
+func (plan *CatalogPlan) Count(action CatalogAction) int {
+	count := 0
+	for _, change := range plan.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// String renders the plan for humans, use encoding/json for a machine readable plan
+func (plan *CatalogPlan) String() string {
+	var output strings.Builder
+	for _, change := range plan.Changes {
+		output.WriteString(change.String())
+		output.WriteString("\n")
+	}
+	fmt.Fprintf(&output, "Plan: %d to create, %d to update, %d to delete.",
+		plan.Count(CatalogActionCreate), plan.Count(CatalogActionUpdate), plan.Count(CatalogActionDelete))
+	return output.String()
+}
+
+func (change CatalogChange) String() string {
+	symbol := map[CatalogAction]string{
+		CatalogActionCreate: "+",
+		CatalogActionUpdate: "~",
+		CatalogActionDelete: "-",
+	}[change.Action]
+	var output strings.Builder
+	fmt.Fprintf(&output, "%s %s %s", symbol, change.Kind, change.Alias)
+	if change.Service != "" {
+		fmt.Fprintf(&output, " (service %s)", change.Service)
+	}
+	for _, field := range change.Fields {
+		fmt.Fprintf(&output, "\n    %s: %q -> %q", field.Field, field.From, field.To)
+	}
+	return output.String()
+}
+
+// PlanCatalog compares 'spec' against the live account and returns the changes needed to make them match
+func (client *Client) PlanCatalog(spec CatalogSpec, options CatalogOptions) (*CatalogPlan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid catalog spec: %w", err)
+	}
+	live, err := client.loadCatalogState()
+	if err != nil {
+		return nil, err
+	}
+	planner := &catalogPlanner{spec: spec, live: live, options: options}
+	if err := planner.checkReferences(); err != nil {
+		return nil, err
+	}
+	planner.planDomains()
+	planner.planSystems()
+	planner.planTeams()
+	planner.planServices()
+	if err := errors.Join(planner.errs...); err != nil {
+		return nil, err
+	}
+	return planner.plan(), nil
+}
+
+// ApplyCatalog plans and applies 'spec' in one step
+func (client *Client) ApplyCatalog(spec CatalogSpec, options CatalogOptions) (*CatalogPlan, []CatalogChange, error) {
+	plan, err := client.PlanCatalog(spec, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	applied, err := plan.Apply(client)
+	return plan, applied, err
+}
+
+// Apply runs the changes in dependency order, domains → systems → teams → services → tags → tools,
+// with deletes in the reverse order. It stops at the first error and returns the changes that were applied.
+func (plan *CatalogPlan) Apply(client *Client) ([]CatalogChange, error) {
+	applier := &catalogApplier{client: client, teams: map[string]ID{}}
+	maps.Copy(applier.teams, plan.teams)
+	var applied []CatalogChange
+	deferredRan := false
+	for _, change := range plan.Changes {
+		// Owners of domains and systems created before their team are set once teams exist
+		if !deferredRan && (change.Action == CatalogActionDelete || slices.Index(catalogApplyOrder, change.Kind) > slices.Index(catalogApplyOrder, CatalogResourceKindTeam)) {
+			if err := applier.runDeferred(); err != nil {
+				return applied, err
+			}
+			deferredRan = true
+		}
+		if change.apply == nil {
+			return applied, fmt.Errorf("%s %s '%s' cannot be applied, plans must come from PlanCatalog", change.Action, change.Kind, change.Alias)
+		}
+		if err := change.apply(applier); err != nil {
+			return applied, fmt.Errorf("unable to %s %s '%s': %w", change.Action, change.Kind, change.Alias, err)
+		}
+		applied = append(applied, change)
+	}
+	if !deferredRan {
+		if err := applier.runDeferred(); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// Live state
+
+type catalogState struct {
+	domains  []Domain
+	systems  []System
+	teams    []Team
+	services []Service
+}
+
+func (client *Client) loadCatalogState() (*catalogState, error) {
+	domains, err := client.ListDomains(nil)
+	if err != nil {
+		return nil, err
+	}
+	systems, err := client.ListSystems(nil)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := client.ListTeams(nil)
+	if err != nil {
+		return nil, err
+	}
+	services, err := client.ListServices(nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range services.Nodes {
+		if err := services.Nodes[i].Hydrate(client); err != nil {
+			return nil, err
+		}
+	}
+	return &catalogState{
+		domains:  domains.Nodes,
+		systems:  systems.Nodes,
+		teams:    teams.Nodes,
+		services: services.Nodes,
+	}, nil
+}
+
+func (state *catalogState) domain(alias string) *Domain {
+	for i, domain := range state.domains {
+		if slices.Contains(domain.Aliases, alias) {
+			return &state.domains[i]
+		}
+	}
+	return nil
+}
+
+func (state *catalogState) system(alias string) *System {
+	for i, system := range state.systems {
+		if slices.Contains(system.Aliases, alias) {
+			return &state.systems[i]
+		}
+	}
+	return nil
+}
+
+func (state *catalogState) team(alias string) *Team {
+	for i, team := range state.teams {
+		if team.Alias == alias || slices.Contains(team.Aliases, alias) {
+			return &state.teams[i]
+		}
+	}
+	return nil
+}
+
+func (state *catalogState) service(alias string) *Service {
+	for i, service := range state.services {
+		if slices.Contains(service.Aliases, alias) {
+			return &state.services[i]
+		}
+	}
+	return nil
+}
+
+// Planning
+
+type catalogPlanner struct {
+	spec    CatalogSpec
+	live    *catalogState
+	options CatalogOptions
+	changes []CatalogChange
+	errs    []error
+}
+
+// checkReferences makes sure every referenced alias is either in the spec or the live account
+func (planner *catalogPlanner) checkReferences() error {
+	var errs []error
+	exists := func(kind CatalogResourceKind, alias string) bool {
+		switch kind {
+		case CatalogResourceKindDomain:
+			return planner.live.domain(alias) != nil || slices.ContainsFunc(planner.spec.Domains, func(d CatalogDomainSpec) bool { return d.Alias == alias })
+		case CatalogResourceKindSystem:
+			return planner.live.system(alias) != nil || slices.ContainsFunc(planner.spec.Systems, func(s CatalogSystemSpec) bool { return s.Alias == alias })
+		case CatalogResourceKindTeam:
+			return planner.live.team(alias) != nil || slices.ContainsFunc(planner.spec.Teams, func(t CatalogTeamSpec) bool { return t.Alias == alias })
+		}
+		return false
+	}
+	reference := func(from CatalogResourceKind, fromAlias string, kind CatalogResourceKind, alias string) {
+		if alias != "" && !exists(kind, alias) {
+			errs = append(errs, fmt.Errorf("%s '%s' references %s '%s' which does not exist", from, fromAlias, kind, alias))
+		}
+	}
+	for _, domain := range planner.spec.Domains {
+		reference(CatalogResourceKindDomain, domain.Alias, CatalogResourceKindTeam, domain.Owner)
+	}
+	for _, system := range planner.spec.Systems {
+		reference(CatalogResourceKindSystem, system.Alias, CatalogResourceKindTeam, system.Owner)
+		reference(CatalogResourceKindSystem, system.Alias, CatalogResourceKindDomain, system.Domain)
+	}
+	for _, team := range planner.spec.Teams {
+		reference(CatalogResourceKindTeam, team.Alias, CatalogResourceKindTeam, team.Parent)
+	}
+	for _, service := range planner.spec.Services {
+		reference(CatalogResourceKindService, service.Alias, CatalogResourceKindTeam, service.Owner)
+		reference(CatalogResourceKindService, service.Alias, CatalogResourceKindSystem, service.System)
+	}
+	return errors.Join(errs...)
+}
+
+func (planner *catalogPlanner) add(change CatalogChange) {
+	planner.changes = append(planner.changes, change)
+}
+
+// plan orders creates and updates by catalogApplyOrder followed by deletes in reverse order
+func (planner *catalogPlanner) plan() *CatalogPlan {
+	output := &CatalogPlan{Changes: []CatalogChange{}, teams: map[string]ID{}}
+	for _, team := range planner.live.teams {
+		output.teams[team.Alias] = team.Id
+		for _, alias := range team.Aliases {
+			output.teams[alias] = team.Id
+		}
+	}
+	for _, kind := range catalogApplyOrder {
+		for _, change := range planner.changes {
+			if change.Kind == kind && change.Action != CatalogActionDelete {
+				output.Changes = append(output.Changes, change)
+			}
+		}
+	}
+	for i := len(catalogApplyOrder) - 1; i >= 0; i-- {
+		for _, change := range planner.changes {
+			if change.Kind == catalogApplyOrder[i] && change.Action == CatalogActionDelete {
+				output.Changes = append(output.Changes, change)
+			}
+		}
+	}
+	return output
+}
+
+// diffField records a change when 'desired' is managed and differs from 'current'
+func diffField(fields []CatalogFieldChange, field string, current string, desired string) []CatalogFieldChange {
+	if desired == "" || desired == current {
+		return fields
+	}
+	return append(fields, CatalogFieldChange{Field: field, From: current, To: desired})
+}
+
+// diffReference is diffField for references where the live resource may have several aliases
+func diffReference(fields []CatalogFieldChange, field string, currentAliases []string, desired string) []CatalogFieldChange {
+	if desired == "" || slices.Contains(currentAliases, desired) {
+		return fields
+	}
+	current := ""
+	if len(currentAliases) > 0 {
+		current = currentAliases[0]
+	}
+	return append(fields, CatalogFieldChange{Field: field, From: current, To: desired})
+}
+
+// ownerAliases returns every alias of the live team 'owner' so an owner named by any of them is not a change
+func (planner *catalogPlanner) ownerAliases(owner TeamId) []string {
+	output := []string{owner.Alias}
+	if owner.Id == "" {
+		return output
+	}
+	for _, team := range planner.live.teams {
+		if team.Id == owner.Id {
+			output = append(output, team.Aliases...)
+		}
+	}
+	return output
+}
+
+func hasFieldChange(fields []CatalogFieldChange, field string) bool {
+	return slices.ContainsFunc(fields, func(f CatalogFieldChange) bool { return f.Field == field })
+}
+
+func (planner *catalogPlanner) planDomains() {
+	if planner.spec.Domains == nil {
+		return
+	}
+	for _, spec := range planner.spec.Domains {
+		spec := spec
+		current := planner.live.domain(spec.Alias)
+		if current == nil {
+			var fields []CatalogFieldChange
+			fields = diffField(fields, "name", "", spec.Name)
+			fields = diffField(fields, "description", "", spec.Description)
+			fields = diffField(fields, "owner", "", spec.Owner)
+			fields = diffField(fields, "note", "", spec.Note)
+			planner.add(CatalogChange{Action: CatalogActionCreate, Kind: CatalogResourceKindDomain, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+				input := DomainInput{Name: &spec.Name, Description: nilIfEmpty(spec.Description), Note: nilIfEmpty(spec.Note)}
+				created, err := applier.client.CreateDomain(input)
+				if err != nil {
+					return err
+				}
+				if err := applier.ensureAlias(created.Id, created.Aliases, spec.Alias); err != nil {
+					return err
+				}
+				return applier.setOwner(spec.Owner, func(owner ID) error {
+					_, err := applier.client.UpdateDomain(string(created.Id), DomainInput{OwnerId: &owner})
+					return err
+				})
+			}})
+			continue
+		}
+		var fields []CatalogFieldChange
+		fields = diffField(fields, "name", current.Name, spec.Name)
+		fields = diffField(fields, "description", current.Description, spec.Description)
+		fields = diffReference(fields, "owner", planner.ownerAliases(current.Owner.OnTeam.AsTeam()), spec.Owner)
+		fields = diffField(fields, "note", current.Note, spec.Note)
+		if len(fields) == 0 {
+			continue
+		}
+		id := current.Id
+		planner.add(CatalogChange{Action: CatalogActionUpdate, Kind: CatalogResourceKindDomain, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+			input := DomainInput{}
+			if hasFieldChange(fields, "name") {
+				input.Name = &spec.Name
+			}
+			if hasFieldChange(fields, "description") {
+				input.Description = &spec.Description
+			}
+			if hasFieldChange(fields, "note") {
+				input.Note = &spec.Note
+			}
+			if input != (DomainInput{}) {
+				if _, err := applier.client.UpdateDomain(string(id), input); err != nil {
+					return err
+				}
+			}
+			if !hasFieldChange(fields, "owner") {
+				return nil
+			}
+			return applier.setOwner(spec.Owner, func(owner ID) error {
+				_, err := applier.client.UpdateDomain(string(id), DomainInput{OwnerId: &owner})
+				return err
+			})
+		}})
+	}
+	if !planner.options.Prune {
+		return
+	}
+	for _, domain := range planner.live.domains {
+		if slices.ContainsFunc(planner.spec.Domains, func(spec CatalogDomainSpec) bool { return slices.Contains(domain.Aliases, spec.Alias) }) {
+			continue
+		}
+		id := domain.Id
+		planner.add(CatalogChange{Action: CatalogActionDelete, Kind: CatalogResourceKindDomain, Alias: firstAlias(domain.Aliases, id), apply: func(applier *catalogApplier) error {
+			return applier.client.DeleteDomain(string(id))
+		}})
+	}
+}
+
+func (planner *catalogPlanner) planSystems() {
+	if planner.spec.Systems == nil {
+		return
+	}
+	for _, spec := range planner.spec.Systems {
+		spec := spec
+		current := planner.live.system(spec.Alias)
+		if current == nil {
+			var fields []CatalogFieldChange
+			fields = diffField(fields, "name", "", spec.Name)
+			fields = diffField(fields, "description", "", spec.Description)
+			fields = diffField(fields, "owner", "", spec.Owner)
+			fields = diffField(fields, "domain", "", spec.Domain)
+			fields = diffField(fields, "note", "", spec.Note)
+			planner.add(CatalogChange{Action: CatalogActionCreate, Kind: CatalogResourceKindSystem, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+				input := SystemInput{Name: &spec.Name, Description: nilIfEmpty(spec.Description), Note: nilIfEmpty(spec.Note)}
+				if spec.Domain != "" {
+					input.Parent = NewIdentifier(spec.Domain)
+				}
+				created, err := applier.client.CreateSystem(input)
+				if err != nil {
+					return err
+				}
+				if err := applier.ensureAlias(created.Id, created.Aliases, spec.Alias); err != nil {
+					return err
+				}
+				return applier.setOwner(spec.Owner, func(owner ID) error {
+					_, err := applier.client.UpdateSystem(string(created.Id), SystemInput{OwnerId: &owner})
+					return err
+				})
+			}})
+			continue
+		}
+		var fields []CatalogFieldChange
+		fields = diffField(fields, "name", current.Name, spec.Name)
+		fields = diffField(fields, "description", current.Description, spec.Description)
+		fields = diffReference(fields, "owner", planner.ownerAliases(current.Owner.OnTeam.AsTeam()), spec.Owner)
+		fields = diffReference(fields, "domain", current.Parent.Aliases, spec.Domain)
+		fields = diffField(fields, "note", current.Note, spec.Note)
+		if len(fields) == 0 {
+			continue
+		}
+		id := current.Id
+		planner.add(CatalogChange{Action: CatalogActionUpdate, Kind: CatalogResourceKindSystem, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+			input := SystemInput{}
+			if hasFieldChange(fields, "name") {
+				input.Name = &spec.Name
+			}
+			if hasFieldChange(fields, "description") {
+				input.Description = &spec.Description
+			}
+			if hasFieldChange(fields, "domain") {
+				input.Parent = NewIdentifier(spec.Domain)
+			}
+			if hasFieldChange(fields, "note") {
+				input.Note = &spec.Note
+			}
+			if input != (SystemInput{}) {
+				if _, err := applier.client.UpdateSystem(string(id), input); err != nil {
+					return err
+				}
+			}
+			if !hasFieldChange(fields, "owner") {
+				return nil
+			}
+			return applier.setOwner(spec.Owner, func(owner ID) error {
+				_, err := applier.client.UpdateSystem(string(id), SystemInput{OwnerId: &owner})
+				return err
+			})
+		}})
+	}
+	if !planner.options.Prune {
+		return
+	}
+	for _, system := range planner.live.systems {
+		if slices.ContainsFunc(planner.spec.Systems, func(spec CatalogSystemSpec) bool { return slices.Contains(system.Aliases, spec.Alias) }) {
+			continue
+		}
+		id := system.Id
+		planner.add(CatalogChange{Action: CatalogActionDelete, Kind: CatalogResourceKindSystem, Alias: firstAlias(system.Aliases, id), apply: func(applier *catalogApplier) error {
+			return applier.client.DeleteSystem(string(id))
+		}})
+	}
+}
+
+func (planner *catalogPlanner) planTeams() {
+	if planner.spec.Teams == nil {
+		return
+	}
+	for _, spec := range planner.spec.Teams {
+		spec := spec
+		current := planner.live.team(spec.Alias)
+		if current == nil {
+			var fields []CatalogFieldChange
+			fields = diffField(fields, "name", "", spec.Name)
+			fields = diffField(fields, "responsibilities", "", spec.Responsibilities)
+			fields = diffField(fields, "parent", "", spec.Parent)
+			planner.add(CatalogChange{Action: CatalogActionCreate, Kind: CatalogResourceKindTeam, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+				input := TeamCreateInput{Name: spec.Name, Responsibilities: nilIfEmpty(spec.Responsibilities)}
+				if spec.Parent != "" {
+					input.ParentTeam = NewIdentifier(spec.Parent)
+				}
+				created, err := applier.client.CreateTeam(input)
+				if err != nil {
+					return err
+				}
+				applier.teams[spec.Alias] = created.Id
+				return applier.ensureAlias(created.Id, append(created.Aliases, created.Alias), spec.Alias)
+			}})
+			continue
+		}
+		var fields []CatalogFieldChange
+		fields = diffField(fields, "name", current.Name, spec.Name)
+		fields = diffField(fields, "responsibilities", current.Responsibilities, spec.Responsibilities)
+		fields = diffField(fields, "parent", current.ParentTeam.Alias, spec.Parent)
+		if len(fields) == 0 {
+			continue
+		}
+		id := current.Id
+		planner.add(CatalogChange{Action: CatalogActionUpdate, Kind: CatalogResourceKindTeam, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+			input := TeamUpdateInput{Id: &id}
+			if hasFieldChange(fields, "name") {
+				input.Name = &spec.Name
+			}
+			if hasFieldChange(fields, "responsibilities") {
+				input.Responsibilities = &spec.Responsibilities
+			}
+			if hasFieldChange(fields, "parent") {
+				input.ParentTeam = NewIdentifier(spec.Parent)
+			}
+			_, err := applier.client.UpdateTeam(input)
+			return err
+		}})
+	}
+	if !planner.options.Prune {
+		return
+	}
+	for _, team := range planner.live.teams {
+		if slices.ContainsFunc(planner.spec.Teams, func(spec CatalogTeamSpec) bool {
+			return team.Alias == spec.Alias || slices.Contains(team.Aliases, spec.Alias)
+		}) {
+			continue
+		}
+		id := team.Id
+		planner.add(CatalogChange{Action: CatalogActionDelete, Kind: CatalogResourceKindTeam, Alias: team.Alias, apply: func(applier *catalogApplier) error {
+			return applier.client.DeleteTeam(string(id))
+		}})
+	}
+}
+
+func (planner *catalogPlanner) planServices() {
+	if planner.spec.Services == nil {
+		return
+	}
+	for _, spec := range planner.spec.Services {
+		spec := spec
+		current := planner.live.service(spec.Alias)
+		if current == nil {
+			var fields []CatalogFieldChange
+			fields = diffField(fields, "name", "", spec.Name)
+			fields = diffField(fields, "description", "", spec.Description)
+			fields = diffField(fields, "owner", "", spec.Owner)
+			fields = diffField(fields, "system", "", spec.System)
+			fields = diffField(fields, "tier", "", spec.Tier)
+			fields = diffField(fields, "lifecycle", "", spec.Lifecycle)
+			fields = diffField(fields, "language", "", spec.Language)
+			fields = diffField(fields, "framework", "", spec.Framework)
+			fields = diffField(fields, "product", "", spec.Product)
+			planner.add(CatalogChange{Action: CatalogActionCreate, Kind: CatalogResourceKindService, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+				input := ServiceCreateInput{
+					Name:           spec.Name,
+					Description:    nilIfEmpty(spec.Description),
+					TierAlias:      nilIfEmpty(spec.Tier),
+					LifecycleAlias: nilIfEmpty(spec.Lifecycle),
+					Language:       nilIfEmpty(spec.Language),
+					Framework:      nilIfEmpty(spec.Framework),
+					Product:        nilIfEmpty(spec.Product),
+				}
+				if spec.Owner != "" {
+					input.OwnerInput = NewIdentifier(spec.Owner)
+				}
+				if spec.System != "" {
+					input.Parent = NewIdentifier(spec.System)
+				}
+				created, err := applier.client.CreateService(input)
+				if err != nil {
+					return err
+				}
+				return applier.ensureAlias(created.Id, created.Aliases, spec.Alias)
+			}})
+			planner.planTags(spec, nil)
+			planner.planTools(spec, nil)
+			continue
+		}
+		var fields []CatalogFieldChange
+		fields = diffField(fields, "name", current.Name, spec.Name)
+		fields = diffField(fields, "description", current.Description, spec.Description)
+		fields = diffReference(fields, "owner", planner.ownerAliases(current.Owner), spec.Owner)
+		if spec.System != "" {
+			var parentAliases []string
+			if current.Parent != nil {
+				parentAliases = current.Parent.Aliases
+			}
+			fields = diffReference(fields, "system", parentAliases, spec.System)
+		}
+		fields = diffField(fields, "tier", current.Tier.Alias, spec.Tier)
+		fields = diffField(fields, "lifecycle", current.Lifecycle.Alias, spec.Lifecycle)
+		fields = diffField(fields, "language", current.Language, spec.Language)
+		fields = diffField(fields, "framework", current.Framework, spec.Framework)
+		fields = diffField(fields, "product", current.Product, spec.Product)
+		if len(fields) > 0 {
+			id := current.Id
+			planner.add(CatalogChange{Action: CatalogActionUpdate, Kind: CatalogResourceKindService, Alias: spec.Alias, Fields: fields, apply: func(applier *catalogApplier) error {
+				input := ServiceUpdateInputV2{Id: &id}
+				for _, field := range fields {
+					switch field.Field {
+					case "name":
+						input.Name = NewNullableFrom(field.To)
+					case "description":
+						input.Description = NewNullableFrom(field.To)
+					case "owner":
+						input.OwnerInput = NewIdentifier(field.To)
+					case "system":
+						input.Parent = NewIdentifier(field.To)
+					case "tier":
+						input.TierAlias = NewNullableFrom(field.To)
+					case "lifecycle":
+						input.LifecycleAlias = NewNullableFrom(field.To)
+					case "language":
+						input.Language = NewNullableFrom(field.To)
+					case "framework":
+						input.Framework = NewNullableFrom(field.To)
+					case "product":
+						input.Product = NewNullableFrom(field.To)
+					}
+				}
+				_, err := applier.client.UpdateService(input)
+				return err
+			}})
+		}
+		planner.planTags(spec, current)
+		planner.planTools(spec, current)
+	}
+	if !planner.options.Prune {
+		return
+	}
+	for _, service := range planner.live.services {
+		if slices.ContainsFunc(planner.spec.Services, func(spec CatalogServiceSpec) bool { return slices.Contains(service.Aliases, spec.Alias) }) {
+			continue
+		}
+		id := service.Id
+		planner.add(CatalogChange{Action: CatalogActionDelete, Kind: CatalogResourceKindService, Alias: firstAlias(service.Aliases, id), apply: func(applier *catalogApplier) error {
+			return applier.client.DeleteService(string(id))
+		}})
+	}
+}
+
+func (planner *catalogPlanner) planTags(spec CatalogServiceSpec, current *Service) {
+	if spec.Tags == nil {
+		return
+	}
+	var existing []Tag
+	if current != nil && current.Tags != nil {
+		existing = current.Tags.Nodes
+	}
+	serviceType := TaggableResourceService
+	for _, input := range extractTagInputsToCreate(existing, tagInputsToTags(spec.Tags)) {
+		input := input
+		planner.add(CatalogChange{Action: CatalogActionCreate, Kind: CatalogResourceKindTag, Alias: input.Key + ":" + input.Value, Service: spec.Alias, apply: func(applier *catalogApplier) error {
+			_, err := applier.client.CreateTag(TagCreateInput{Alias: &spec.Alias, Type: &serviceType, Key: input.Key, Value: input.Value})
+			return err
+		}})
+	}
+	if !planner.options.Prune {
+		return
+	}
+	for _, tag := range existing {
+		tag := tag
+		if slices.ContainsFunc(spec.Tags, func(t TagInput) bool { return t.Key == tag.Key && t.Value == tag.Value }) {
+			continue
+		}
+		planner.add(CatalogChange{Action: CatalogActionDelete, Kind: CatalogResourceKindTag, Alias: tag.Flatten(), Service: spec.Alias, apply: func(applier *catalogApplier) error {
+			return applier.client.DeleteTag(tag.Id)
+		}})
+	}
+}
+
+func (planner *catalogPlanner) planTools(spec CatalogServiceSpec, current *Service) {
+	if spec.Tools == nil {
+		return
+	}
+	if current == nil {
+		current = &Service{}
+	}
+	diff, err := current.DiffTools(spec.Tools)
+	if err != nil {
+		planner.errs = append(planner.errs, fmt.Errorf("service '%s': %w", spec.Alias, err))
+		return
+	}
+	for _, input := range diff.Create {
+		input := input
+		input.ServiceId = nil
+		input.ServiceAlias = &spec.Alias
+		key := toolKey(input.Category, input.DisplayName, "")
+		if input.Environment != nil {
+			key = toolKey(input.Category, input.DisplayName, *input.Environment)
+		}
+		planner.add(CatalogChange{Action: CatalogActionCreate, Kind: CatalogResourceKindTool, Alias: key, Service: spec.Alias, Fields: diffField(nil, "url", "", input.Url), apply: func(applier *catalogApplier) error {
+			_, err := applier.client.CreateTool(input)
+			return err
+		}})
+	}
+	for _, input := range diff.Update {
+		input := input
+		tool := current.Tools.Nodes[slices.IndexFunc(current.Tools.Nodes, func(t Tool) bool { return t.Id == input.Id })]
+		key := toolKey(tool.Category, tool.DisplayName, tool.Environment)
+		planner.add(CatalogChange{Action: CatalogActionUpdate, Kind: CatalogResourceKindTool, Alias: key, Service: spec.Alias, Fields: diffField(nil, "url", tool.Url, *input.Url), apply: func(applier *catalogApplier) error {
+			_, err := applier.client.UpdateTool(input)
+			return err
+		}})
+	}
+	if !planner.options.Prune {
+		return
+	}
+	for _, tool := range diff.Delete {
+		tool := tool
+		planner.add(CatalogChange{Action: CatalogActionDelete, Kind: CatalogResourceKindTool, Alias: toolKey(tool.Category, tool.DisplayName, tool.Environment), Service: spec.Alias, apply: func(applier *catalogApplier) error {
+			return applier.client.DeleteTool(tool.Id)
+		}})
+	}
+}
+
+func tagInputsToTags(inputs []TagInput) []Tag {
+	output := make([]Tag, len(inputs))
+	for i, input := range inputs {
+		output[i] = Tag{Key: input.Key, Value: input.Value}
+	}
+	return output
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func firstAlias(aliases []string, id ID) string {
+	if len(aliases) > 0 {
+		return aliases[0]
+	}
+	return string(id)
+}
+
+// Applying
+
+type catalogApplier struct {
+	client   *Client
+	teams    map[string]ID // Team alias to id, including teams created while applying
+	deferred []func() error
+}
+
+// setOwner calls 'update' with the owner's id once the owning team exists
+func (applier *catalogApplier) setOwner(alias string, update func(owner ID) error) error {
+	if alias == "" {
+		return nil
+	}
+	if id, ok := applier.teams[alias]; ok {
+		return update(id)
+	}
+	applier.deferred = append(applier.deferred, func() error {
+		id, ok := applier.teams[alias]
+		if !ok {
+			return fmt.Errorf("owner team '%s' does not exist", alias)
+		}
+		return update(id)
+	})
+	return nil
+}
+
+func (applier *catalogApplier) runDeferred() error {
+	for _, fn := range applier.deferred {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	applier.deferred = nil
+	return nil
+}
+
+// ensureAlias adds 'alias' to a newly created resource whose generated aliases do not include it
+func (applier *catalogApplier) ensureAlias(id ID, aliases []string, alias string) error {
+	if slices.Contains(aliases, alias) {
+		return nil
+	}
+	_, err := applier.client.CreateAlias(AliasCreateInput{Alias: alias, OwnerId: id})
+	return err
+}
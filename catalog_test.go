@@ -0,0 +1,330 @@
+package opslevel_test
+
+import (
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestLoadCatalogSpec(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		error string
+	}{
+		"valid": {
+			input: `
+version: 1
+domains:
+  - alias: platform
+    name: Platform
+teams:
+  - alias: devs
+    name: Developers
+services:
+  - alias: catalog_api
+    name: Catalog API
+    owner: devs
+    tags:
+      - key: env
+        value: prod
+    tools:
+      - category: metrics
+        displayName: Datadog
+        url: https://datadog.example.com
+`,
+		},
+		"missing version": {
+			input: `domains: []`,
+			error: "invalid catalog spec: version must be 1. Given: '0'",
+		},
+		"duplicate alias": {
+			input: `
+version: 1
+teams:
+  - alias: devs
+    name: Developers
+  - alias: devs
+    name: Developers Again
+`,
+			error: "invalid catalog spec: team 'devs' is defined more than once",
+		},
+		"missing name": {
+			input: `
+version: 1
+domains:
+  - alias: platform
+`,
+			error: "invalid catalog spec: domain 'platform' is missing a name",
+		},
+		"invalid tool category": {
+			input: `
+version: 1
+services:
+  - alias: catalog_api
+    name: Catalog API
+    tools:
+      - category: nope
+        displayName: Nope
+        url: https://example.com
+`,
+			error: "invalid catalog spec: service 'catalog_api' tool 'Nope' category must be one of",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			spec, err := ol.LoadCatalogSpec([]byte(tc.input))
+			// Assert
+			if tc.error == "" {
+				autopilot.Ok(t, err)
+				autopilot.Equals(t, 1, len(spec.Services))
+				autopilot.Equals(t, ol.ToolCategoryMetrics, spec.Services[0].Tools[0].Category)
+				return
+			}
+			autopilot.Assert(t, err != nil, "expected an error")
+			autopilot.Assert(t, strings.HasPrefix(err.Error(), tc.error), err.Error())
+		})
+	}
+}
+
+func TestPlanCatalog(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`{{ template "catalog_domain_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "domains": { "nodes": [ { {{ template "id1" }}, "aliases": ["platform"], "name": "Platform", "description": "Old", "owner": { "teamAlias": "devs", {{ template "id2" }} } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`{{ template "catalog_system_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "systems": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [ { "alias": "devs", {{ template "id2" }}, "aliases": ["devs"], "name": "Developers" } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`{{ template "catalog_service_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "services": { "nodes": [
+			{ {{ template "id3" }}, "aliases": ["catalog_api"], "name": "Catalog API", "owner": { "alias": "devs", {{ template "id2" }} }, "tier": { "alias": "tier_2" },
+			  "tags": { "nodes": [ { {{ template "id1" }}, "key": "env", "value": "prod" }, { {{ template "id2" }}, "key": "old", "value": "true" } ] },
+			  "tools": { "nodes": [ { "category": "metrics", "displayName": "Datadog", "environment": "prod", {{ template "id4" }}, "url": "https://old.example.com" } ] } },
+			{ {{ template "id4" }}, "aliases": ["legacy"], "name": "Legacy" }
+		], {{ template "no_pagination_response" }} }}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree, testRequestFour}
+
+	client := BestTestClient(t, "catalog/plan", requests...)
+	spec, err := ol.LoadCatalogSpec([]byte(`
+version: 1
+domains:
+  - alias: platform
+    name: Platform
+    description: Platform services
+  - alias: payments
+    name: Payments
+    owner: payments_team
+teams:
+  - alias: devs
+    name: Developers
+  - alias: payments_team
+    name: Payments
+services:
+  - alias: catalog_api
+    name: Catalog API
+    owner: payments_team
+    tier: tier_1
+    tags:
+      - key: env
+        value: prod
+      - key: team
+        value: payments
+    tools:
+      - category: metrics
+        displayName: Datadog
+        environment: prod
+        url: https://new.example.com
+`))
+	autopilot.Ok(t, err)
+	// Act
+	plan, err := client.PlanCatalog(*spec, ol.CatalogOptions{Prune: true})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `~ domain platform
+    description: "Old" -> "Platform services"
++ domain payments
+    name: "" -> "Payments"
+    owner: "" -> "payments_team"
++ team payments_team
+    name: "" -> "Payments"
+~ service catalog_api
+    owner: "devs" -> "payments_team"
+    tier: "tier_2" -> "tier_1"
++ tag team:payments (service catalog_api)
+~ tool metrics/Datadog/prod (service catalog_api)
+    url: "https://old.example.com" -> "https://new.example.com"
+- tag old:true (service catalog_api)
+- service legacy
+Plan: 3 to create, 3 to update, 2 to delete.`, plan.String())
+}
+
+func TestPlanCatalogMissingReference(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`{{ template "catalog_domain_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "domains": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`{{ template "catalog_system_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "systems": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`{{ template "catalog_service_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "services": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree, testRequestFour}
+
+	client := BestTestClient(t, "catalog/plan_missing_reference", requests...)
+	spec := ol.CatalogSpec{
+		Version: 1,
+		Systems: []ol.CatalogSystemSpec{{Alias: "checkout", Name: "Checkout", Domain: "payments"}},
+	}
+	// Act
+	_, err := client.PlanCatalog(spec, ol.CatalogOptions{})
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error")
+	autopilot.Equals(t, "system 'checkout' references domain 'payments' which does not exist", err.Error())
+}
+
+func TestApplyCatalog(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`{{ template "catalog_domain_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "domains": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`{{ template "catalog_system_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "systems": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`{{ template "catalog_service_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "services": { "nodes": [ { {{ template "id4" }}, "aliases": ["legacy"], "name": "Legacy" } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFive := autopilot.NewTestRequest(
+		`mutation DomainCreate($input:DomainInput!){domainCreate(input:$input){domain{id,aliases,description,htmlUrl,managedAliases,name,note,owner{... on Team{teamAlias:alias,id}}},errors{message,path}}}`,
+		`{"input": {"name": "Payments"}}`,
+		`{"data": {"domainCreate": {"domain": { {{ template "id1" }}, "aliases": ["payments"], "name": "Payments" }, "errors": [] }}}`,
+	)
+	testRequestSix := autopilot.NewTestRequest(
+		`mutation TeamCreate($input:TeamCreateInput!){teamCreate(input: $input){team{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},errors{message,path}}}`,
+		`{"input": {"name": "Payments"}}`,
+		`{"data": {"teamCreate": {"team": { "alias": "payments", {{ template "id2" }}, "aliases": ["payments"], "name": "Payments" }, "errors": [] }}}`,
+	)
+	testRequestSeven := autopilot.NewTestRequest(
+		`mutation AliasCreate($input:AliasCreateInput!){aliasCreate(input: $input){aliases,ownerId,errors{message,path}}}`,
+		`{"input": {"alias": "payments_team", "ownerId": "{{ template "id2_string" }}"}}`,
+		`{"data": {"aliasCreate": {"aliases": ["payments", "payments_team"], "ownerId": "{{ template "id2_string" }}", "errors": [] }}}`,
+	)
+	testRequestEight := autopilot.NewTestRequest(
+		`mutation DomainUpdate($domain:IdentifierInput!$input:DomainInput!){domainUpdate(domain:$domain,input:$input){domain{id,aliases,description,htmlUrl,managedAliases,name,note,owner{... on Team{teamAlias:alias,id}}},errors{message,path}}}`,
+		`{"domain": { {{ template "id1" }} }, "input": {"ownerId": "{{ template "id2_string" }}"}}`,
+		`{"data": {"domainUpdate": {"domain": { {{ template "id1" }}, "aliases": ["payments"], "name": "Payments" }, "errors": [] }}}`,
+	)
+	testRequestNine := autopilot.NewTestRequest(
+		`mutation ServiceDelete($input:ServiceDeleteInput!){serviceDelete(input: $input){deletedServiceId,deletedServiceAlias,errors{message,path}}}`,
+		`{"input": { {{ template "id4" }} }}`,
+		`{"data": {"serviceDelete": {"deletedServiceId": "{{ template "id4_string" }}", "deletedServiceAlias": "legacy", "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{
+		testRequestOne, testRequestTwo, testRequestThree, testRequestFour, testRequestFive,
+		testRequestSix, testRequestSeven, testRequestEight, testRequestNine,
+	}
+
+	client := BestTestClient(t, "catalog/apply", requests...)
+	spec := ol.CatalogSpec{
+		Version:  1,
+		Domains:  []ol.CatalogDomainSpec{{Alias: "payments", Name: "Payments", Owner: "payments_team"}},
+		Teams:    []ol.CatalogTeamSpec{{Alias: "payments_team", Name: "Payments"}},
+		Services: []ol.CatalogServiceSpec{},
+	}
+	// Act
+	plan, applied, err := client.ApplyCatalog(spec, ol.CatalogOptions{Prune: true})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, len(plan.Changes))
+	autopilot.Equals(t, len(plan.Changes), len(applied))
+	autopilot.Equals(t, ol.CatalogActionDelete, applied[2].Action)
+	autopilot.Equals(t, "legacy", applied[2].Alias)
+}
+
+func TestApplyCatalogOwnerByAnyAlias(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`{{ template "catalog_domain_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "domains": { "nodes": [ { {{ template "id1" }}, "aliases": ["platform"], "name": "Platform", "owner": { "teamAlias": "devs", {{ template "id2" }} } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`{{ template "catalog_system_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "systems": { "nodes": [ { {{ template "id3" }}, "aliases": ["core"], "name": "Core", "owner": { "teamAlias": "devs", {{ template "id2" }} } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [
+			{ "alias": "devs", {{ template "id2" }}, "aliases": ["devs", "developers"], "name": "Developers" },
+			{ "alias": "ops", {{ template "id4" }}, "aliases": ["ops"], "name": "Operations" }
+		], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`{{ template "catalog_service_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "services": { "nodes": [
+			{ "id": "Z2lkOi8vMzAx", "aliases": ["catalog_api"], "name": "Catalog API", "owner": { "alias": "devs", {{ template "id2" }} } }
+		], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFive := autopilot.NewTestRequest(
+		`mutation SystemUpdate($input:SystemInput!$system:IdentifierInput!){systemUpdate(system:$system,input:$input){system{id,aliases,name,description,htmlUrl,owner{... on Team{teamAlias:alias,id}},parent{id,aliases,description,htmlUrl,managedAliases,name,note,owner{... on Team{teamAlias:alias,id}}},note},errors{message,path}}}`,
+		`{"system": { {{ template "id3" }} }, "input": {"ownerId": "{{ template "id4_string" }}"}}`,
+		`{"data": {"systemUpdate": {"system": { {{ template "id3" }}, "aliases": ["core"], "name": "Core", "owner": { "teamAlias": "ops", {{ template "id4" }} } }, "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree, testRequestFour, testRequestFive}
+
+	client := BestTestClient(t, "catalog/apply_owner_by_any_alias", requests...)
+	spec := ol.CatalogSpec{
+		Version:  1,
+		Domains:  []ol.CatalogDomainSpec{{Alias: "platform", Name: "Platform", Owner: "developers"}},
+		Systems:  []ol.CatalogSystemSpec{{Alias: "core", Name: "Core", Owner: "ops"}},
+		Services: []ol.CatalogServiceSpec{{Alias: "catalog_api", Name: "Catalog API", Owner: "developers"}},
+	}
+	// Act
+	plan, applied, err := client.ApplyCatalog(spec, ol.CatalogOptions{})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `~ system core
+    owner: "devs" -> "ops"
+Plan: 0 to create, 1 to update, 0 to delete.`, plan.String())
+	autopilot.Equals(t, 1, len(applied))
+}
@@ -0,0 +1,110 @@
+package opslevel
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/relvacode/iso8601"
+)
+
+// CheckSummary is the subset of a check needed to report on its result
+type CheckSummary struct {
+	Category Category `graphql:"category"`
+	Id       ID       `graphql:"id"`
+	Level    Level    `graphql:"level"`
+	Name     string   `graphql:"name"`
+}
+
+type CheckResult struct {
+	Check           CheckSummary          `graphql:"check"`
+	LastEvaluatedAt iso8601.Time          `graphql:"lastEvaluatedAt"`
+	Message         string                `graphql:"message"`
+	Service         ServiceId             `graphql:"service"`
+	Status          CheckResultStatusEnum `graphql:"status"`
+}
+
+type CheckResultConnection struct {
+	Nodes      []CheckResult
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+func (checkResult *CheckResult) Passed() bool {
+	return checkResult.Status == CheckResultStatusEnumPassed
+}
+
+// Failing returns the check results that did not pass
+func (checkResultConnection *CheckResultConnection) Failing() []CheckResult {
+	var output []CheckResult
+	for _, node := range checkResultConnection.Nodes {
+		if !node.Passed() {
+			output = append(output, node)
+		}
+	}
+	return output
+}
+
+func (service *Service) GetCheckResults(client *Client, variables *PayloadVariables) (*CheckResultConnection, error) {
+	var q struct {
+		Account struct {
+			Service struct {
+				CheckResults CheckResultConnection `graphql:"checkResults(after: $after, first: $first)"`
+			} `graphql:"service(id: $service)"`
+		}
+	}
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to get CheckResults, invalid service id: '%s'", service.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["service"] = service.Id
+	if err := client.Query(&q, *variables, WithName("ServiceCheckResultsList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Service.CheckResults.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Service.CheckResults.PageInfo.End
+		resp, err := service.GetCheckResults(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Service.CheckResults.Nodes = append(q.Account.Service.CheckResults.Nodes, resp.Nodes...)
+		q.Account.Service.CheckResults.PageInfo = resp.PageInfo
+		q.Account.Service.CheckResults.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Service.CheckResults, nil
+}
+
+func (client *Client) ListCheckResults(status CheckResultStatusEnum, variables *PayloadVariables) (*CheckResultConnection, error) {
+	var q struct {
+		Account struct {
+			CheckResults CheckResultConnection `graphql:"checkResults(status: $status, after: $after, first: $first)"`
+		}
+	}
+	if !slices.Contains(AllCheckResultStatusEnum, string(status)) {
+		return nil, fmt.Errorf("status must be one of %v. Given: '%s'", AllCheckResultStatusEnum, status)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["status"] = status
+	if err := client.Query(&q, *variables, WithName("CheckResultsList")); err != nil {
+		return nil, err
+	}
+	for q.Account.CheckResults.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.CheckResults.PageInfo.End
+		resp, err := client.ListCheckResults(status, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.CheckResults.Nodes = append(q.Account.CheckResults.Nodes, resp.Nodes...)
+		q.Account.CheckResults.PageInfo = resp.PageInfo
+		q.Account.CheckResults.TotalCount += resp.TotalCount
+	}
+	return &q.Account.CheckResults, nil
+}
+
+// ListFailingCheckResults returns every failing check result across the account
+func (client *Client) ListFailingCheckResults(variables *PayloadVariables) (*CheckResultConnection, error) {
+	return client.ListCheckResults(CheckResultStatusEnumFailed, variables)
+}
@@ -0,0 +1,69 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestServiceGetCheckResults(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceCheckResultsList($after:String!$first:Int!$service:ID!){account{service(id: $service){checkResults(after: $after, first: $first){nodes{ {{- template "check_result_request" -}} },{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{ "data": { "account": { "service": { "checkResults": { "nodes": [ {{ template "check_result_1" }}, {{ template "check_result_2" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 2 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query ServiceCheckResultsList($after:String!$first:Int!$service:ID!){account{service(id: $service){checkResults(after: $after, first: $first){nodes{ {{- template "check_result_request" -}} },{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "second_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{ "data": { "account": { "service": { "checkResults": { "nodes": [ {{ template "check_result_3" }} ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "check_results/service", requests...)
+	service := ol.Service{
+		ServiceId: ol.ServiceId{
+			Id: id1,
+		},
+	}
+	// Act
+	resp, err := service.GetCheckResults(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, resp.TotalCount)
+	autopilot.Equals(t, "Has Owner", resp.Nodes[0].Check.Name)
+	autopilot.Equals(t, ol.CheckResultStatusEnumFailed, resp.Nodes[0].Status)
+	autopilot.Equals(t, 2, resp.Nodes[0].Check.Level.Index)
+	autopilot.Equals(t, "Service does not have an owner", resp.Nodes[0].Message)
+	autopilot.Equals(t, true, resp.Nodes[1].Passed())
+	failing := resp.Failing()
+	autopilot.Equals(t, 2, len(failing))
+	autopilot.Equals(t, "Has README", failing[1].Check.Name)
+}
+
+func TestListFailingCheckResults(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query CheckResultsList($after:String!$first:Int!$status:CheckResultStatusEnum!){account{checkResults(status: $status, after: $after, first: $first){nodes{ {{- template "check_result_request" -}} },{{ template "pagination_request" }},totalCount}}}`,
+		`{ {{ template "first_page_variables" }}, "status": "failed" }`,
+		`{ "data": { "account": { "checkResults": { "nodes": [ {{ template "check_result_1" }}, {{ template "check_result_3" }} ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+	client := BestTestClient(t, "check_results/list_failing", testRequest)
+	// Act
+	resp, err := client.ListFailingCheckResults(nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, resp.TotalCount)
+	autopilot.Equals(t, id1, resp.Nodes[0].Service.Id)
+	autopilot.Equals(t, []string{"example_service"}, resp.Nodes[1].Service.Aliases)
+}
+
+func TestListCheckResultsInvalidStatus(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "check_results/list_invalid")
+	// Act
+	_, err := client.ListCheckResults(ol.CheckResultStatusEnum("pending"), nil)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown check result status")
+}
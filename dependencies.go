@@ -1,6 +1,10 @@
 package opslevel
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
 
 type ServiceDependency struct {
 	Id        ID        `graphql:"id"`
@@ -125,3 +129,87 @@ func (client *Client) DeleteServiceDependency(id ID) error {
 	err := client.Mutate(&m, v, WithName("ServiceDependencyDelete"))
 	return HandleErrors(err, m.Payload.Errors)
 }
+
+// ServiceDependencyReconcileResult lists what ReconcileDependencies changed and what it had to leave in place
+type ServiceDependencyReconcileResult struct {
+	Created []ServiceDependency
+	Deleted []ServiceDependenciesEdge
+	Locked  []ServiceDependenciesEdge // Unwanted dependencies that are locked and were not deleted
+}
+
+// ReconcileDependencies makes the services this service depends on match 'dependenciesWanted', a list of service aliases or ids
+//
+// Wanted dependencies that already exist are left untouched so their notes are preserved.
+// Unwanted dependencies are deleted unless they are locked, ex: managed by an opslevel.yml.
+func (service *Service) ReconcileDependencies(client *Client, dependenciesWanted []string) (*ServiceDependencyReconcileResult, error) {
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to reconcile Dependencies, invalid service id: '%s'", service.Id)
+	}
+	for _, identifier := range dependenciesWanted {
+		if service.isIdentifiedBy(identifier) {
+			return nil, fmt.Errorf("service '%s' can not depend on itself", identifier)
+		}
+	}
+	if service.Dependencies == nil || service.Dependencies.PageInfo.HasNextPage {
+		service.Dependencies = nil
+		if _, err := service.GetDependencies(client, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var allErrors error
+	result := &ServiceDependencyReconcileResult{}
+	for _, edge := range service.Dependencies.Edges {
+		if slices.ContainsFunc(dependenciesWanted, edge.isDependencyOn) {
+			continue
+		}
+		if edge.Locked {
+			result.Locked = append(result.Locked, edge)
+			continue
+		}
+		if err := client.DeleteServiceDependency(edge.Id); err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		result.Deleted = append(result.Deleted, edge)
+	}
+
+	for _, identifier := range dependenciesWanted {
+		// Created dependencies are compared by the service they resolved to, so the same service wanted by alias and by id is created once
+		if slices.ContainsFunc(service.Dependencies.Edges, func(edge ServiceDependenciesEdge) bool {
+			return edge.isDependencyOn(identifier)
+		}) || slices.ContainsFunc(result.Created, func(dependency ServiceDependency) bool {
+			return dependency.DependsOn.isIdentifiedBy(identifier)
+		}) {
+			continue
+		}
+		dependency, err := client.CreateServiceDependency(ServiceDependencyCreateInput{
+			DependencyKey: ServiceDependencyKey{
+				SourceIdentifier:      NewIdentifier(string(service.Id)),
+				DestinationIdentifier: NewIdentifier(identifier),
+			},
+		})
+		if err != nil {
+			allErrors = errors.Join(allErrors, fmt.Errorf("unable to depend on '%s': %w", identifier, err))
+			continue
+		}
+		result.Created = append(result.Created, *dependency)
+	}
+
+	service.Dependencies.Edges = slices.DeleteFunc(service.Dependencies.Edges, func(edge ServiceDependenciesEdge) bool {
+		return slices.ContainsFunc(result.Deleted, func(deleted ServiceDependenciesEdge) bool { return deleted.Id == edge.Id })
+	})
+	for _, dependency := range result.Created {
+		node := dependency.DependsOn
+		service.Dependencies.Edges = append(service.Dependencies.Edges, ServiceDependenciesEdge{Id: dependency.Id, Node: &node, Notes: dependency.Notes})
+	}
+	return result, allErrors
+}
+
+func (edge ServiceDependenciesEdge) isDependencyOn(identifier string) bool {
+	return edge.Node != nil && (string(edge.Node.Id) == identifier || slices.Contains(edge.Node.Aliases, identifier))
+}
+
+func (serviceId ServiceId) isIdentifiedBy(identifier string) bool {
+	return string(serviceId.Id) == identifier || slices.Contains(serviceId.Aliases, identifier)
+}
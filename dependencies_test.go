@@ -102,3 +102,73 @@ func TestDeleteServiceDependency(t *testing.T) {
 	// Assert
 	autopilot.Ok(t, err)
 }
+
+func TestServiceReconcileDependencies(t *testing.T) {
+	// Arrange
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1, Aliases: []string{"checkout"}}}
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceDependenciesList($after:String!$first:Int!$service:ID!){account{service(id: $service){dependencies(after: $after, first: $first){edges{id,locked,node{id,aliases},notes},{{ template "pagination_request" }}}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{"data": {"account": { "service": { "dependencies": { "edges": [
+			{ {{ template "id2" }}, "locked": false, "node": { "id": "Z2lkOi8vMTAx", "aliases": ["payments"] }, "notes": "Charges cards" },
+			{ {{ template "id3" }}, "locked": true, "node": { "id": "Z2lkOi8vMTAy", "aliases": ["legacy_auth"] }, "notes": "" },
+			{ {{ template "id4" }}, "locked": false, "node": { "id": "Z2lkOi8vMTAz", "aliases": ["inventory"] }, "notes": "" }
+		], {{ template "no_pagination_response" }} }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation ServiceDependencyDelete($input:DeleteInput!){serviceDependencyDelete(input: $input){errors{message,path}}}`,
+		`{ "input": { {{ template "id4" }} } }`,
+		`{"data": { "serviceDependencyDelete": { "errors": [] }}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation ServiceDependencyCreate($input:ServiceDependencyCreateInput!){serviceDependencyCreate(inputV2: $input){serviceDependency{id,sourceService{id,aliases},destinationService{id,aliases},notes},errors{message,path}}}`,
+		`{ "input": { "dependencyKey": { "destinationIdentifier": {"alias": "shipping"}, "sourceIdentifier": { {{ template "id1" }} } } }}`,
+		`{"data": { "serviceDependencyCreate": { "serviceDependency": { "id": "Z2lkOi8vMjAx", "sourceService": { {{ template "id1" }}, "aliases": ["checkout"] }, "destinationService": { "id": "Z2lkOi8vMTA0", "aliases": ["shipping"] }, "notes": "" }, "errors": [] } }}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "service/reconcile_dependencies", requests...)
+	// Act
+	result, err := service.ReconcileDependencies(client, []string{"payments", "shipping", "shipping"})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Created))
+	autopilot.Equals(t, []string{"shipping"}, result.Created[0].DependsOn.Aliases)
+	autopilot.Equals(t, 1, len(result.Deleted))
+	autopilot.Equals(t, id4, result.Deleted[0].Id)
+	autopilot.Equals(t, 1, len(result.Locked))
+	autopilot.Equals(t, id3, result.Locked[0].Id)
+	autopilot.Equals(t, 3, len(service.Dependencies.Edges))
+	autopilot.Equals(t, "Charges cards", service.Dependencies.Edges[0].Notes)
+}
+
+func TestServiceReconcileDependenciesByAliasAndId(t *testing.T) {
+	// Arrange
+	service := ol.Service{
+		ServiceId:    ol.ServiceId{Id: id1, Aliases: []string{"checkout"}},
+		Dependencies: &ol.ServiceDependenciesConnection{},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`mutation ServiceDependencyCreate($input:ServiceDependencyCreateInput!){serviceDependencyCreate(inputV2: $input){serviceDependency{id,sourceService{id,aliases},destinationService{id,aliases},notes},errors{message,path}}}`,
+		`{ "input": { "dependencyKey": { "destinationIdentifier": {"alias": "shipping"}, "sourceIdentifier": { {{ template "id1" }} } } }}`,
+		`{"data": { "serviceDependencyCreate": { "serviceDependency": { "id": "Z2lkOi8vMjAx", "sourceService": { {{ template "id1" }}, "aliases": ["checkout"] }, "destinationService": { "id": "Z2lkOi8vMTA0", "aliases": ["shipping"] }, "notes": "" }, "errors": [] } }}`,
+	)
+
+	client := BestTestClient(t, "service/reconcile_dependencies_by_alias_and_id", testRequestOne)
+	// Act
+	result, err := service.ReconcileDependencies(client, []string{"shipping", "Z2lkOi8vMTA0"})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Created))
+	autopilot.Equals(t, 1, len(service.Dependencies.Edges))
+}
+
+func TestServiceReconcileDependenciesOnItself(t *testing.T) {
+	// Arrange
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1, Aliases: []string{"checkout"}}}
+	client := BestTestClient(t, "service/reconcile_dependencies_on_itself")
+	// Act
+	_, err := service.ReconcileDependencies(client, []string{"checkout"})
+	// Assert
+	autopilot.Equals(t, "service 'checkout' can not depend on itself", err.Error())
+}
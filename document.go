@@ -1,5 +1,16 @@
 package opslevel
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"gopkg.in/yaml.v3"
+)
+
 type ServiceDocumentSource struct {
 	IntegrationId     `graphql:"... on ApiDocIntegration"`
 	ServiceRepository `graphql:"... on ServiceRepository"`
@@ -17,6 +28,21 @@ type ServiceDocumentContent struct {
 	Content string `graphql:"content" json:"content,omitempty"`
 }
 
+// ResourceDocumentStatusUpdateInput specifies the input fields used in the `resourceDocumentStatusUpdate` mutation.
+type ResourceDocumentStatusUpdateInput struct {
+	Document ID                             `json:"document" yaml:"document" example:"Z2lkOi8vc2VydmljZS8xMjM0NTY3ODk"` // The document to update. (Required.)
+	Resource IdentifierInput                `json:"resource" yaml:"resource"`                                           // The resource the document belongs to. (Required.)
+	Status   ResourceDocumentStatusTypeEnum `json:"status" yaml:"status" example:"hidden"`                              // The status of the document on the resource. (Required.)
+}
+
+// ApiDocumentSpec describes an API document that passed ValidateApiDocument
+type ApiDocumentSpec struct {
+	Format     string // One of "openapi", "swagger" or "asyncapi"
+	Version    string // The version of the specification format, ex: "3.0.3"
+	Title      string
+	ApiVersion string // The version of the documented API
+}
+
 func (client *Client) ServiceApiDocSettingsUpdate(service string, docPath string, docSource *ApiDocumentSourceEnum) (*Service, error) {
 	var m struct {
 		Payload struct {
@@ -35,3 +61,168 @@ func (client *Client) ServiceApiDocSettingsUpdate(service string, docPath string
 	err := client.Mutate(&m, v, WithName("ServiceApiDocSettingsUpdate"))
 	return &m.Payload.Service, HandleErrors(err, m.Payload.Errors)
 }
+
+func (service *Service) GetDocumentContent(client *Client, id ID) (*ServiceDocumentContent, error) {
+	var q struct {
+		Account struct {
+			Service struct {
+				Document ServiceDocumentContent `graphql:"document(id: $document)"`
+			} `graphql:"service(id: $service)"`
+		}
+	}
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to get DocumentContent, invalid service id: '%s'", service.Id)
+	}
+	v := PayloadVariables{
+		"service":  service.Id,
+		"document": id,
+	}
+	err := client.Query(&q, v, WithName("ServiceDocumentContentGet"))
+	if err == nil && q.Account.Service.Document.Id == "" {
+		err = fmt.Errorf("document with ID '%s' not found on service '%s'", id, service.Id)
+	}
+	return &q.Account.Service.Document, HandleErrors(err, nil)
+}
+
+// UpdateDocumentStatus pins, shows or hides a document on the given resource
+func (client *Client) UpdateDocumentStatus(input ResourceDocumentStatusUpdateInput) (*ServiceDocument, error) {
+	var m struct {
+		Payload struct {
+			Document ServiceDocument
+			Errors   []OpsLevelErrors
+		} `graphql:"resourceDocumentStatusUpdate(input: $input)"`
+	}
+	if !slices.Contains(AllResourceDocumentStatusTypeEnum, string(input.Status)) {
+		return nil, fmt.Errorf("document status must be one of %v. Given: '%s'", AllResourceDocumentStatusTypeEnum, input.Status)
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("ResourceDocumentStatusUpdate"))
+	return &m.Payload.Document, HandleErrors(err, m.Payload.Errors)
+}
+
+// UploadApiDocument validates 'document' and uploads it to the API document integration for the
+// service, 'client' must be a rest client pointed at the OpsLevel upload host, ex: https://upload.opslevel.com
+func UploadApiDocument(client *resty.Client, integration ID, service string, document []byte) (*RestResponse, error) {
+	if _, err := ValidateApiDocument(document); err != nil {
+		return nil, err
+	}
+	contentType := "application/x-yaml"
+	if trimmed := bytes.TrimSpace(document); len(trimmed) > 0 && trimmed[0] == '{' {
+		contentType = "application/json"
+	}
+	output := &RestResponse{}
+	resp, err := client.R().
+		SetHeader("Content-Type", contentType).
+		SetBody(document).
+		SetResult(output).
+		SetError(output).
+		Post(fmt.Sprintf("/upload/apidocs/%s/%s", integration, service))
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return output, fmt.Errorf("unable to upload api document for service '%s': %s %s", service, resp.Status(), output.Message)
+	}
+	return output, nil
+}
+
+// ValidateApiDocument checks that 'document' is JSON or YAML for an OpenAPI 3, Swagger 2 or AsyncAPI specification
+func ValidateApiDocument(document []byte) (*ApiDocumentSpec, error) {
+	var parsed map[string]any
+	if err := yaml.Unmarshal(document, &parsed); err != nil {
+		return nil, fmt.Errorf("api document must be valid JSON or YAML: %w", err)
+	}
+	if parsed == nil {
+		return nil, errors.New("api document is empty")
+	}
+	// Versions are read as written, an unquoted `swagger: 2.0` decodes to the number 2
+	var root yaml.Node
+	if err := yaml.Unmarshal(document, &root); err != nil {
+		return nil, fmt.Errorf("api document must be valid JSON or YAML: %w", err)
+	}
+
+	output := &ApiDocumentSpec{}
+	var errs []error
+	switch {
+	case parsed["openapi"] != nil:
+		output.Format = "openapi"
+		output.Version = apiDocumentScalar(&root, "openapi")
+		if !strings.HasPrefix(output.Version, "3.") {
+			errs = append(errs, fmt.Errorf("openapi version must be 3.x. Given: '%s'", output.Version))
+		}
+		// OpenAPI 3.1 allows documents that only define webhooks or components
+		if parsed["paths"] == nil && (!strings.HasPrefix(output.Version, "3.1") || (parsed["webhooks"] == nil && parsed["components"] == nil)) {
+			errs = append(errs, errors.New("openapi document must define 'paths'"))
+		}
+	case parsed["swagger"] != nil:
+		output.Format = "swagger"
+		output.Version = apiDocumentScalar(&root, "swagger")
+		if output.Version != "2.0" {
+			errs = append(errs, fmt.Errorf("swagger version must be 2.0. Given: '%s'", output.Version))
+		}
+		if parsed["paths"] == nil {
+			errs = append(errs, errors.New("swagger document must define 'paths'"))
+		}
+	case parsed["asyncapi"] != nil:
+		output.Format = "asyncapi"
+		output.Version = apiDocumentScalar(&root, "asyncapi")
+		if !strings.HasPrefix(output.Version, "2.") && !strings.HasPrefix(output.Version, "3.") {
+			errs = append(errs, fmt.Errorf("asyncapi version must be 2.x or 3.x. Given: '%s'", output.Version))
+		}
+	default:
+		return nil, errors.New("api document must declare one of 'openapi', 'swagger' or 'asyncapi'")
+	}
+
+	info, ok := parsed["info"].(map[string]any)
+	if !ok {
+		errs = append(errs, errors.New("api document must define 'info'"))
+	} else {
+		output.Title, _ = info["title"].(string)
+		if output.Title == "" {
+			errs = append(errs, errors.New("api document must define 'info.title'"))
+		}
+		if info["version"] == nil {
+			errs = append(errs, errors.New("api document must define 'info.version'"))
+		} else {
+			output.ApiVersion = apiDocumentScalar(&root, "info", "version")
+		}
+	}
+	if paths, ok := parsed["paths"]; ok && paths != nil {
+		if _, ok := paths.(map[string]any); !ok {
+			errs = append(errs, errors.New("api document 'paths' must be an object"))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// apiDocumentScalar returns the text of the scalar at 'path' exactly as it is written in the document
+func apiDocumentScalar(root *yaml.Node, path ...string) string {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return ""
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+			}
+		}
+		if next == nil {
+			return ""
+		}
+		node = next
+	}
+	if node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
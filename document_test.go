@@ -1,6 +1,8 @@
 package opslevel_test
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
 
 	ol "github.com/opslevel/opslevel-go/v2024"
@@ -11,7 +13,7 @@ import (
 func TestServiceApiDocSettingsUpdate(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`mutation ServiceApiDocSettingsUpdate($docPath:String$docSource:ApiDocumentSourceEnum$service:IdentifierInput!){serviceApiDocSettingsUpdate(service: $service, apiDocumentPath: $docPath, preferredApiDocumentSource: $docSource){service{apiDocumentPath,description,framework,htmlUrl,id,aliases,language,lifecycle{alias,description,id,index,name},locked,managedAliases,name,owner{alias,id},parent{id,aliases},preferredApiDocument{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},preferredApiDocumentSource,product,repos{edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},defaultServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},timestamps{createdAt,updatedAt},tools{nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}},errors{message,path}}}`,
+		`mutation ServiceApiDocSettingsUpdate($docPath:String$docSource:ApiDocumentSourceEnum$service:IdentifierInput!){serviceApiDocSettingsUpdate(service: $service, apiDocumentPath: $docPath, preferredApiDocumentSource: $docSource){service{apiDocumentPath,description,externalUuid,framework,htmlUrl,id,aliases,language,lifecycle{alias,description,id,index,name},locked,managedAliases,name,note,owner{alias,id},parent{id,aliases},preferredApiDocument{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},preferredApiDocumentSource,product,repos{edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},defaultServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},timestamps{createdAt,updatedAt},tools{nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}},errors{message,path}}}`,
 		`{"docPath":"/src/swagger.json", "docSource":"PULL", "service": {"alias":"service_alias" }}`,
 		`{"data": {"serviceApiDocSettingsUpdate": {"service": {{ template "service_1" }}, "errors": [] }}}`,
 	)
@@ -31,7 +33,7 @@ func TestServiceApiDocSettingsUpdate(t *testing.T) {
 func TestServiceApiDocSettingsUpdateDocSourceNull(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`mutation ServiceApiDocSettingsUpdate($docPath:String$docSource:ApiDocumentSourceEnum$service:IdentifierInput!){serviceApiDocSettingsUpdate(service: $service, apiDocumentPath: $docPath, preferredApiDocumentSource: $docSource){service{apiDocumentPath,description,framework,htmlUrl,id,aliases,language,lifecycle{alias,description,id,index,name},locked,managedAliases,name,owner{alias,id},parent{id,aliases},preferredApiDocument{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},preferredApiDocumentSource,product,repos{edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},defaultServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},timestamps{createdAt,updatedAt},tools{nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}},errors{message,path}}}`,
+		`mutation ServiceApiDocSettingsUpdate($docPath:String$docSource:ApiDocumentSourceEnum$service:IdentifierInput!){serviceApiDocSettingsUpdate(service: $service, apiDocumentPath: $docPath, preferredApiDocumentSource: $docSource){service{apiDocumentPath,description,externalUuid,framework,htmlUrl,id,aliases,language,lifecycle{alias,description,id,index,name},locked,managedAliases,name,note,owner{alias,id},parent{id,aliases},preferredApiDocument{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},preferredApiDocumentSource,product,repos{edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},defaultServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},timestamps{createdAt,updatedAt},tools{nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}},errors{message,path}}}`,
 		`{"docPath":"/src/swagger.json", "docSource": null, "service": {"alias":"service_alias" }}`,
 		`{"data": { "serviceApiDocSettingsUpdate": { "service": {{ template "service_1" }}, "errors": [] }}}`,
 	)
@@ -49,7 +51,7 @@ func TestServiceApiDocSettingsUpdateDocSourceNull(t *testing.T) {
 func TestServiceApiDocSettingsUpdateDocPathNull(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`mutation ServiceApiDocSettingsUpdate($docPath:String$docSource:ApiDocumentSourceEnum$service:IdentifierInput!){serviceApiDocSettingsUpdate(service: $service, apiDocumentPath: $docPath, preferredApiDocumentSource: $docSource){service{apiDocumentPath,description,framework,htmlUrl,id,aliases,language,lifecycle{alias,description,id,index,name},locked,managedAliases,name,owner{alias,id},parent{id,aliases},preferredApiDocument{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},preferredApiDocumentSource,product,repos{edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},defaultServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},timestamps{createdAt,updatedAt},tools{nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}},errors{message,path}}}`,
+		`mutation ServiceApiDocSettingsUpdate($docPath:String$docSource:ApiDocumentSourceEnum$service:IdentifierInput!){serviceApiDocSettingsUpdate(service: $service, apiDocumentPath: $docPath, preferredApiDocumentSource: $docSource){service{apiDocumentPath,description,externalUuid,framework,htmlUrl,id,aliases,language,lifecycle{alias,description,id,index,name},locked,managedAliases,name,note,owner{alias,id},parent{id,aliases},preferredApiDocument{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},preferredApiDocumentSource,product,repos{edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},defaultServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},timestamps{createdAt,updatedAt},tools{nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}},errors{message,path}}}`,
 		`{"docPath":null, "docSource":"PULL", "service": {"alias":"service_alias" }}`,
 		`{"data": { "serviceApiDocSettingsUpdate": { "service": {{ template "service_1" }}, "errors": [] }}}`,
 	)
@@ -64,3 +66,150 @@ func TestServiceApiDocSettingsUpdateDocPathNull(t *testing.T) {
 	autopilot.Equals(t, ol.ApiDocumentSourceEnumPull, *result.PreferredApiDocumentSource)
 	autopilot.Equals(t, "/src/swagger.json", result.ApiDocumentPath)
 }
+
+const testOpenApiDocument = `openapi: 3.0.3
+info:
+  title: Catalog API
+  version: 1.2.0
+paths:
+  /services:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+
+func TestValidateApiDocument(t *testing.T) {
+	testcases := map[string]struct {
+		document string
+		format   string
+		version  string
+	}{
+		"openapi yaml":              {testOpenApiDocument, "openapi", "3.0.3"},
+		"swagger json":              {`{"swagger": "2.0", "info": {"title": "Catalog API", "version": "1"}, "paths": {}}`, "swagger", "2.0"},
+		"openapi 3.1 webhooks only": {`{"openapi": "3.1.0", "info": {"title": "Events", "version": "1"}, "webhooks": {}}`, "openapi", "3.1.0"},
+		"unquoted swagger":          {"swagger: 2.0\ninfo:\n  title: Catalog API\n  version: 1.0\npaths: {}\n", "swagger", "2.0"},
+		"unquoted openapi":          {"openapi: 3.0\ninfo:\n  title: Catalog API\n  version: 1\npaths: {}\n", "openapi", "3.0"},
+		"unquoted json swagger":     {`{"swagger": 2.0, "info": {"title": "Catalog API", "version": 1.0}, "paths": {}}`, "swagger", "2.0"},
+		"asyncapi":                  {"asyncapi: 2.6.0\ninfo:\n  title: Events\n  version: 1.0.0\nchannels: {}\n", "asyncapi", "2.6.0"},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result, err := ol.ValidateApiDocument([]byte(tc.document))
+			// Assert
+			autopilot.Ok(t, err)
+			autopilot.Equals(t, tc.format, result.Format)
+			autopilot.Equals(t, tc.version, result.Version)
+		})
+	}
+}
+
+func TestValidateApiDocumentUnquotedApiVersion(t *testing.T) {
+	// Act
+	result, err := ol.ValidateApiDocument([]byte("openapi: 3.0\ninfo:\n  title: Catalog API\n  version: 1.10\npaths: {}\n"))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "1.10", result.ApiVersion)
+}
+
+func TestValidateApiDocumentErrors(t *testing.T) {
+	testcases := map[string]string{
+		"not yaml":        `openapi: [3.0`,
+		"empty":           ``,
+		"unknown format":  `{"raml": "1.0"}`,
+		"openapi 2":       `{"openapi": "2.0", "info": {"title": "Catalog API", "version": "1"}, "paths": {}}`,
+		"swagger 1.2":     `{"swagger": "1.2", "info": {"title": "Catalog API", "version": "1"}, "paths": {}}`,
+		"unquoted 2.1":    "swagger: 2.1\ninfo:\n  title: Catalog API\n  version: 1\npaths: {}\n",
+		"missing info":    `{"openapi": "3.0.0", "paths": {}}`,
+		"missing title":   `{"openapi": "3.0.0", "info": {"version": "1"}, "paths": {}}`,
+		"missing paths":   `{"openapi": "3.0.0", "info": {"title": "Catalog API", "version": "1"}}`,
+		"paths not a map": `{"swagger": "2.0", "info": {"title": "Catalog API", "version": "1"}, "paths": []}`,
+	}
+	for name, document := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := ol.ValidateApiDocument([]byte(document))
+			// Assert
+			autopilot.Assert(t, err != nil, "expected an error")
+		})
+	}
+}
+
+func TestUploadApiDocument(t *testing.T) {
+	// Arrange
+	autopilot.RegisterEndpoint(
+		fmt.Sprintf("/upload/apidocs/%s/catalog_api", id2),
+		func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{ "result": "ok" }`)
+		},
+		func(r *http.Request) {
+			autopilot.Equals(t, http.MethodPost, r.Method)
+			autopilot.Equals(t, "application/x-yaml", r.Header.Get("Content-Type"))
+		},
+	)
+	client := ol.NewRestClient(ol.SetURL(autopilot.Server.URL))
+	// Act
+	result, err := ol.UploadApiDocument(client, id2, "catalog_api", []byte(testOpenApiDocument))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "ok", result.Result)
+}
+
+func TestUploadApiDocumentInvalid(t *testing.T) {
+	// Arrange
+	client := ol.NewRestClient(ol.SetURL(autopilot.Server.URL))
+	// Act
+	_, err := ol.UploadApiDocument(client, id2, "catalog_api", []byte(`{"openapi": "3.0.0"}`))
+	// Assert
+	autopilot.Assert(t, err != nil, "expected the document to fail validation before upload")
+}
+
+func TestServiceGetDocumentContent(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query ServiceDocumentContentGet($document:ID!$service:ID!){account{service(id: $service){document(id: $document){id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt},content}}}}`,
+		`{"document": "{{ template "id2_string" }}", "service": "{{ template "id1_string" }}"}`,
+		`{"data": {"account": {"service": {"document": { {{ template "id2" }}, "htmlUrl": "https://app.opslevel.com/services/catalog_api/docs", "content": "openapi: 3.0.3" }}}}}`,
+	)
+
+	client := BestTestClient(t, "document/get_content", testRequest)
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	// Act
+	result, err := service.GetDocumentContent(client, id2)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id2, result.Id)
+	autopilot.Equals(t, "openapi: 3.0.3", result.Content)
+}
+
+func TestUpdateDocumentStatus(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation ResourceDocumentStatusUpdate($input:ResourceDocumentStatusUpdateInput!){resourceDocumentStatusUpdate(input: $input){document{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},errors{message,path}}}`,
+		`{"input": {"document": "{{ template "id2_string" }}", "resource": {"alias": "catalog_api"}, "status": "hidden"}}`,
+		`{"data": {"resourceDocumentStatusUpdate": {"document": { {{ template "id2" }} }, "errors": [] }}}`,
+	)
+
+	client := BestTestClient(t, "document/update_status", testRequest)
+	// Act
+	result, err := client.UpdateDocumentStatus(ol.ResourceDocumentStatusUpdateInput{
+		Document: id2,
+		Resource: *ol.NewIdentifier("catalog_api"),
+		Status:   ol.ResourceDocumentStatusTypeEnumHidden,
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id2, result.Id)
+}
+
+func TestUpdateDocumentStatusInvalid(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "document/update_status_invalid")
+	// Act
+	_, err := client.UpdateDocumentStatus(ol.ResourceDocumentStatusUpdateInput{Document: id2, Status: "archived"})
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an invalid status")
+}
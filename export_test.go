@@ -8,4 +8,6 @@ var (
 	ExtractAliases           = extractAliases
 	ExtractTagIdsToDelete    = extractTagIdsToDelete
 	ExtractTagInputsToCreate = extractTagInputsToCreate
+	SnapshotCheckConfig      = snapshotCheckConfig
+	UpsertNoteSection        = upsertNoteSection
 )
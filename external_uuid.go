@@ -0,0 +1,72 @@
+package opslevel
+
+import "fmt"
+
+func (client *Client) CreateExternalUuid(resourceId ID) (string, error) {
+	var m struct {
+		Payload struct {
+			ExternalUuid string           `graphql:"externalUuid"`
+			Errors       []OpsLevelErrors `graphql:"errors"`
+		} `graphql:"externalUuidCreate(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": ExternalUuidMutationInput{ResourceId: resourceId},
+	}
+	err := client.Mutate(&m, v, WithName("ExternalUuidCreate"))
+	return m.Payload.ExternalUuid, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) DeleteExternalUuid(resourceId ID) error {
+	var m struct {
+		Payload struct {
+			Errors []OpsLevelErrors `graphql:"errors"`
+		} `graphql:"externalUuidDelete(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": ExternalUuidMutationInput{ResourceId: resourceId},
+	}
+	err := client.Mutate(&m, v, WithName("ExternalUuidDelete"))
+	return HandleErrors(err, m.Payload.Errors)
+}
+
+func (service *Service) GetExternalUuid(client *Client) (string, error) {
+	var q struct {
+		Account struct {
+			Service struct {
+				ExternalUuid string `graphql:"externalUuid"`
+			} `graphql:"service(id: $service)"`
+		}
+	}
+	if service.Id == "" {
+		return "", fmt.Errorf("unable to get ExternalUuid, invalid service id: '%s'", service.Id)
+	}
+	v := PayloadVariables{
+		"service": service.Id,
+	}
+	if err := client.Query(&q, v, WithName("ServiceExternalUuidGet")); err != nil {
+		return "", err
+	}
+	service.ExternalUuid = q.Account.Service.ExternalUuid
+	return service.ExternalUuid, nil
+}
+
+// GetExternalUuid queries the integration's external UUID, it is not part of the generated Integration fields
+func (integration *Integration) GetExternalUuid(client *Client) (string, error) {
+	var q struct {
+		Account struct {
+			Integration struct {
+				ExternalUuid string `graphql:"externalUuid"`
+			} `graphql:"integration(id: $integration)"`
+		}
+	}
+	if integration.Id == "" {
+		return "", fmt.Errorf("unable to get ExternalUuid, invalid integration id: '%s'", integration.Id)
+	}
+	v := PayloadVariables{
+		"integration": integration.Id,
+	}
+	if err := client.Query(&q, v, WithName("IntegrationExternalUuidGet")); err != nil {
+		return "", err
+	}
+	return q.Account.Integration.ExternalUuid, nil
+}
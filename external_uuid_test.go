@@ -0,0 +1,78 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestCreateExternalUuid(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation ExternalUuidCreate($input:ExternalUuidMutationInput!){externalUuidCreate(input: $input){externalUuid,errors{message,path}}}`,
+		`{"input": { "resourceId": "{{ template "id1_string" }}" }}`,
+		`{"data": { "externalUuidCreate": { "externalUuid": "{{ template "external_uuid_1" }}", "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "external_uuid/create", testRequest)
+	// Act
+	result, err := client.CreateExternalUuid(id1)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "a2f2ba73-b4d1-4a2e-9d7c-5a3c2f1e0b9d", result)
+}
+
+func TestDeleteExternalUuid(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation ExternalUuidDelete($input:ExternalUuidMutationInput!){externalUuidDelete(input: $input){errors{message,path}}}`,
+		`{"input": { "resourceId": "{{ template "id1_string" }}" }}`,
+		`{"data": { "externalUuidDelete": { "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "external_uuid/delete", testRequest)
+	// Act
+	err := client.DeleteExternalUuid(id1)
+	// Assert
+	autopilot.Ok(t, err)
+}
+
+func TestServiceGetExternalUuid(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query ServiceExternalUuidGet($service:ID!){account{service(id: $service){externalUuid}}}`,
+		`{"service": "{{ template "id1_string" }}"}`,
+		`{"data": { "account": { "service": { "externalUuid": "{{ template "external_uuid_1" }}" }}}}`,
+	)
+	client := BestTestClient(t, "external_uuid/service", testRequest)
+	service := ol.Service{
+		ServiceId: ol.ServiceId{
+			Id: id1,
+		},
+	}
+	// Act
+	result, err := service.GetExternalUuid(client)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "a2f2ba73-b4d1-4a2e-9d7c-5a3c2f1e0b9d", result)
+	autopilot.Equals(t, result, service.ExternalUuid)
+}
+
+func TestIntegrationGetExternalUuid(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query IntegrationExternalUuidGet($integration:ID!){account{integration(id: $integration){externalUuid}}}`,
+		`{"integration": "{{ template "id2_string" }}"}`,
+		`{"data": { "account": { "integration": { "externalUuid": null }}}}`,
+	)
+	client := BestTestClient(t, "external_uuid/integration", testRequest)
+	integration := ol.Integration{
+		IntegrationId: ol.IntegrationId{
+			Id: id2,
+		},
+	}
+	// Act
+	result, err := integration.GetExternalUuid(client)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "", result)
+}
@@ -15,6 +15,7 @@ require (
 	github.com/relvacode/iso8601 v1.4.0
 	github.com/rocktavious/autopilot/v2023 v2023.12.7
 	github.com/rs/zerolog v1.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,1489 @@
+package opslevel
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LiquidContext holds the variables OpsLevel makes available to custom action and check message templates
+type LiquidContext struct {
+	Service      *Service
+	User         *User
+	ManualInputs JSON
+	Response     any
+	Payload      any
+	Extra        map[string]any // Additional variables, these take precedence over the ones above
+}
+
+// Variables converts the context into the variable names used inside templates
+func (liquidContext LiquidContext) Variables() (map[string]any, error) {
+	output := map[string]any{}
+	if liquidContext.Service != nil {
+		service, err := toLiquidValue(liquidContext.Service)
+		if err != nil {
+			return nil, err
+		}
+		if serviceMap, ok := service.(map[string]any); ok {
+			if _, ok := serviceMap["alias"]; !ok && len(liquidContext.Service.Aliases) > 0 {
+				serviceMap["alias"] = liquidContext.Service.Aliases[0]
+			}
+		}
+		output["service"] = service
+	}
+	if liquidContext.User != nil {
+		output["user"] = map[string]any{
+			"id":    string(liquidContext.User.Id),
+			"email": liquidContext.User.Email,
+			"name":  liquidContext.User.Name,
+			"role":  string(liquidContext.User.Role),
+		}
+	}
+	for name, value := range map[string]any{
+		"manualInputs": map[string]any(liquidContext.ManualInputs),
+		"response":     liquidContext.Response,
+		"payload":      liquidContext.Payload,
+	} {
+		converted, err := toLiquidValue(value)
+		if err != nil {
+			return nil, err
+		}
+		output[name] = converted
+	}
+	for name, value := range liquidContext.Extra {
+		output[name] = value
+	}
+	return output, nil
+}
+
+// toLiquidValue round trips a value through JSON so structs are exposed by their json field names
+// and whole numbers stay integers, the same as JSON parsed by OpsLevel
+func toLiquidValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if text, ok := value.(string); ok {
+		if decoded, err := decodeLiquidJSON([]byte(text)); err == nil {
+			return decoded, nil
+		}
+		return text, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLiquidJSON(data)
+}
+
+func decodeLiquidJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var output any
+	if err := decoder.Decode(&output); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value at offset %d", decoder.InputOffset())
+	}
+	return fromJSONNumbers(output), nil
+}
+
+func fromJSONNumbers(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		return liquidNumber(value)
+	case map[string]any:
+		for key, item := range value {
+			value[key] = fromJSONNumbers(item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = fromJSONNumbers(item)
+		}
+	}
+	return value
+}
+
+// RenderLiquid renders 'template' the way OpsLevel's Liquid does.
+//
+// All standard tags except include, render and layout are supported, along with the standard
+// filters plus 'json'. Undefined variables render as an empty string. Integers and floats are
+// kept apart like in Liquid, so `{{ 10 | divided_by: 3 }}` renders 3 while `{{ 10.0 | divided_by: 3 }}`
+// renders 3.3333333333333335; use LiquidContext or json.Number values to get integers from JSON.
+func RenderLiquid(template string, variables map[string]any) (string, error) {
+	nodes, err := parseLiquid(template)
+	if err != nil {
+		return "", err
+	}
+	renderer := &liquidRenderer{
+		scope:    map[string]any{},
+		counters: map[string]int{},
+		cycles:   map[string]int{},
+	}
+	for key, value := range variables {
+		renderer.scope[key] = value
+	}
+	var output strings.Builder
+	err = renderer.render(nodes, &output)
+	// A 'break' or 'continue' outside of a loop stops rendering like it does in Liquid
+	if err != nil && !errors.As(err, new(liquidInterrupt)) {
+		return "", err
+	}
+	return output.String(), nil
+}
+
+// RenderLiquidWithContext renders 'template' with the variables from 'liquidContext'
+func RenderLiquidWithContext(template string, liquidContext LiquidContext) (string, error) {
+	variables, err := liquidContext.Variables()
+	if err != nil {
+		return "", err
+	}
+	return RenderLiquid(template, variables)
+}
+
+// Preview renders the LiquidTemplate the way OpsLevel would before sending it to the external action
+func (customActionsExternalAction *CustomActionsExternalAction) Preview(liquidContext LiquidContext) (string, error) {
+	return RenderLiquidWithContext(customActionsExternalAction.LiquidTemplate, liquidContext)
+}
+
+// PreviewResponse renders the ResponseTemplate against the external action's response
+func (customActionsTriggerDefinition *CustomActionsTriggerDefinition) PreviewResponse(liquidContext LiquidContext) (string, error) {
+	return RenderLiquidWithContext(customActionsTriggerDefinition.ResponseTemplate, liquidContext)
+}
+
+// PreviewResultMessage renders the ResultMessage against a custom event payload
+func (customEventCheckFragment *CustomEventCheckFragment) PreviewResultMessage(liquidContext LiquidContext) (string, error) {
+	return RenderLiquidWithContext(customEventCheckFragment.ResultMessage, liquidContext)
+}
+
+// Parsing
+
+type liquidTokenKind int
+
+const (
+	liquidTokenText liquidTokenKind = iota
+	liquidTokenOutput
+	liquidTokenTag
+)
+
+type liquidToken struct {
+	kind    liquidTokenKind
+	content string
+}
+
+func tokenizeLiquid(template string) ([]liquidToken, error) {
+	var output []liquidToken
+	trimNext := false
+	for len(template) > 0 {
+		start := strings.Index(template, "{")
+		for start >= 0 && start+1 < len(template) && template[start+1] != '{' && template[start+1] != '%' {
+			next := strings.Index(template[start+1:], "{")
+			if next < 0 {
+				start = -1
+				break
+			}
+			start += next + 1
+		}
+		if start < 0 || start+1 >= len(template) {
+			output = appendLiquidText(output, template, trimNext)
+			break
+		}
+		text := template[:start]
+		closer := "}}"
+		kind := liquidTokenOutput
+		if template[start+1] == '%' {
+			closer = "%}"
+			kind = liquidTokenTag
+		}
+		end := strings.Index(template[start+2:], closer)
+		if end < 0 {
+			return nil, fmt.Errorf("liquid: unclosed '%s' starting at '%s'", template[start:start+2], truncateLiquid(template[start:]))
+		}
+		content := template[start+2 : start+2+end]
+		template = template[start+2+end+2:]
+
+		if strings.HasPrefix(content, "-") {
+			content = content[1:]
+			text = strings.TrimRightFunc(text, unicode.IsSpace)
+		}
+		output = appendLiquidText(output, text, trimNext)
+		trimNext = false
+		if strings.HasSuffix(content, "-") {
+			content = content[:len(content)-1]
+			trimNext = true
+		}
+		content = strings.TrimSpace(content)
+
+		if kind == liquidTokenTag && content == "raw" {
+			end := strings.Index(template, "{%")
+			for end >= 0 {
+				closing := strings.Index(template[end:], "%}")
+				if closing < 0 {
+					break
+				}
+				if strings.Trim(template[end+2:end+closing], " -") == "endraw" {
+					break
+				}
+				next := strings.Index(template[end+2:], "{%")
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += next + 2
+			}
+			if end < 0 {
+				return nil, errors.New("liquid: 'raw' tag was never closed")
+			}
+			closing := strings.Index(template[end:], "%}")
+			output = append(output, liquidToken{kind: liquidTokenText, content: template[:end]})
+			template = template[end+closing+2:]
+			continue
+		}
+		if kind == liquidTokenTag && strings.HasPrefix(content, "#") {
+			continue
+		}
+		if name, args := splitLiquidTag(content); kind == liquidTokenTag && name == "liquid" {
+			// Every line of a 'liquid' tag is a tag of its own
+			for _, line := range strings.Split(args, "\n") {
+				if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+					output = append(output, liquidToken{kind: liquidTokenTag, content: line})
+				}
+			}
+			continue
+		}
+		output = append(output, liquidToken{kind: kind, content: content})
+	}
+	return output, nil
+}
+
+func appendLiquidText(tokens []liquidToken, text string, trimLeft bool) []liquidToken {
+	if trimLeft {
+		text = strings.TrimLeftFunc(text, unicode.IsSpace)
+	}
+	if text == "" {
+		return tokens
+	}
+	return append(tokens, liquidToken{kind: liquidTokenText, content: text})
+}
+
+func truncateLiquid(value string) string {
+	if len(value) > 20 {
+		return value[:20] + "..."
+	}
+	return value
+}
+
+type liquidNode interface{}
+
+type liquidTextNode struct{ text string }
+
+type liquidOutputNode struct{ expression liquidExpression }
+
+type liquidAssignNode struct {
+	name       string
+	expression liquidExpression
+}
+
+type liquidCaptureNode struct {
+	name string
+	body []liquidNode
+}
+
+type liquidBranch struct {
+	condition *liquidCondition // nil for else
+	body      []liquidNode
+}
+
+type liquidIfNode struct{ branches []liquidBranch }
+
+type liquidCaseNode struct {
+	subject  liquidExpression
+	whens    [][]liquidValue
+	bodies   [][]liquidNode
+	elseBody []liquidNode
+}
+
+// liquidLoop holds what 'for' and 'tablerow' have in common
+type liquidLoop struct {
+	variable   string
+	collection liquidValue
+	limit      *liquidValue
+	offset     *liquidValue
+	cols       *liquidValue // only for 'tablerow'
+	reversed   bool
+}
+
+type liquidForNode struct {
+	loop     liquidLoop
+	body     []liquidNode
+	elseBody []liquidNode
+}
+
+type liquidTablerowNode struct {
+	loop liquidLoop
+	body []liquidNode
+}
+
+type liquidCycleNode struct {
+	group  *liquidValue
+	values []liquidValue
+	key    string
+}
+
+type liquidCounterNode struct {
+	name      string
+	increment bool
+}
+
+// liquidInterruptNode is a 'break' or 'continue' tag
+type liquidInterruptNode struct{ interrupt liquidInterrupt }
+
+// liquidInterrupt is returned while rendering to unwind to the closest loop
+type liquidInterrupt string
+
+const (
+	liquidBreak    liquidInterrupt = "break"
+	liquidContinue liquidInterrupt = "continue"
+)
+
+func (interrupt liquidInterrupt) Error() string {
+	return fmt.Sprintf("liquid: '%s' outside of a loop", string(interrupt))
+}
+
+type liquidParser struct {
+	tokens   []liquidToken
+	position int
+}
+
+func parseLiquid(template string) ([]liquidNode, error) {
+	tokens, err := tokenizeLiquid(template)
+	if err != nil {
+		return nil, err
+	}
+	parser := &liquidParser{tokens: tokens}
+	nodes, stop, err := parser.parseUntil()
+	if err != nil {
+		return nil, err
+	}
+	if stop != "" {
+		return nil, fmt.Errorf("liquid: unexpected '%s'", stop)
+	}
+	return nodes, nil
+}
+
+// parseUntil parses nodes until one of the 'stops' tags and returns the full content of that tag
+func (parser *liquidParser) parseUntil(stops ...string) ([]liquidNode, string, error) {
+	var nodes []liquidNode
+	for parser.position < len(parser.tokens) {
+		token := parser.tokens[parser.position]
+		parser.position++
+		switch token.kind {
+		case liquidTokenText:
+			nodes = append(nodes, liquidTextNode{text: token.content})
+		case liquidTokenOutput:
+			expression, err := parseLiquidExpression(token.content)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, liquidOutputNode{expression: expression})
+		case liquidTokenTag:
+			name, args := splitLiquidTag(token.content)
+			for _, stop := range stops {
+				if name == stop {
+					return nodes, token.content, nil
+				}
+			}
+			node, err := parser.parseTag(name, args)
+			if err != nil {
+				return nil, "", err
+			}
+			if node != nil {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	if len(stops) > 0 {
+		return nil, "", fmt.Errorf("liquid: expected one of %v before the end of the template", stops)
+	}
+	return nodes, "", nil
+}
+
+func splitLiquidTag(content string) (string, string) {
+	name, args, _ := strings.Cut(content, " ")
+	if before, after, ok := strings.Cut(name, "\n"); ok {
+		name, args = before, after+" "+args
+	}
+	return name, strings.TrimSpace(args)
+}
+
+func (parser *liquidParser) parseTag(name string, args string) (liquidNode, error) {
+	switch name {
+	case "if", "unless":
+		return parser.parseIf(name, args)
+	case "case":
+		return parser.parseCase(args)
+	case "for":
+		return parser.parseFor(args)
+	case "tablerow":
+		return parser.parseTablerow(args)
+	case "break":
+		return liquidInterruptNode{interrupt: liquidBreak}, nil
+	case "continue":
+		return liquidInterruptNode{interrupt: liquidContinue}, nil
+	case "assign":
+		variable, value, ok := strings.Cut(args, "=")
+		if !ok {
+			return nil, fmt.Errorf("liquid: invalid assign '%s'", args)
+		}
+		expression, err := parseLiquidExpression(value)
+		if err != nil {
+			return nil, err
+		}
+		return liquidAssignNode{name: strings.TrimSpace(variable), expression: expression}, nil
+	case "capture":
+		body, _, err := parser.parseUntil("endcapture")
+		if err != nil {
+			return nil, err
+		}
+		return liquidCaptureNode{name: args, body: body}, nil
+	case "echo":
+		expression, err := parseLiquidExpression(args)
+		if err != nil {
+			return nil, err
+		}
+		return liquidOutputNode{expression: expression}, nil
+	case "increment", "decrement":
+		if args == "" {
+			return nil, fmt.Errorf("liquid: '%s' is missing a variable", name)
+		}
+		return liquidCounterNode{name: args, increment: name == "increment"}, nil
+	case "cycle":
+		return parseLiquidCycle(args)
+	case "comment":
+		_, _, err := parser.parseUntil("endcomment")
+		return nil, err
+	}
+	return nil, fmt.Errorf("liquid: unknown tag '%s'", name)
+}
+
+func (parser *liquidParser) parseIf(name string, args string) (liquidNode, error) {
+	output := liquidIfNode{}
+	closing := "end" + name
+	condition, err := parseLiquidCondition(args)
+	if err != nil {
+		return nil, err
+	}
+	if name == "unless" {
+		condition.negate = true
+	}
+	for {
+		body, stop, err := parser.parseUntil("elsif", "else", closing)
+		if err != nil {
+			return nil, err
+		}
+		output.branches = append(output.branches, liquidBranch{condition: condition, body: body})
+		stopName, stopArgs := splitLiquidTag(stop)
+		switch stopName {
+		case closing:
+			return output, nil
+		case "else":
+			body, _, err := parser.parseUntil(closing)
+			if err != nil {
+				return nil, err
+			}
+			output.branches = append(output.branches, liquidBranch{body: body})
+			return output, nil
+		default:
+			condition, err = parseLiquidCondition(stopArgs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (parser *liquidParser) parseCase(args string) (liquidNode, error) {
+	subject, err := parseLiquidExpression(args)
+	if err != nil {
+		return nil, err
+	}
+	output := liquidCaseNode{subject: subject}
+	// Anything between 'case' and the first 'when' is ignored
+	_, stop, err := parser.parseUntil("when", "else", "endcase")
+	if err != nil {
+		return nil, err
+	}
+	for {
+		stopName, stopArgs := splitLiquidTag(stop)
+		switch stopName {
+		case "endcase":
+			return output, nil
+		case "else":
+			output.elseBody, _, err = parser.parseUntil("endcase")
+			return output, err
+		}
+		var values []liquidValue
+		for _, part := range splitLiquidList(stopArgs) {
+			value, err := parseLiquidValue(part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		var body []liquidNode
+		body, stop, err = parser.parseUntil("when", "else", "endcase")
+		if err != nil {
+			return nil, err
+		}
+		output.whens = append(output.whens, values)
+		output.bodies = append(output.bodies, body)
+	}
+}
+
+func (parser *liquidParser) parseFor(args string) (liquidNode, error) {
+	loop, err := parseLiquidLoop("for", args)
+	if err != nil {
+		return nil, err
+	}
+	output := liquidForNode{loop: loop}
+	body, stop, err := parser.parseUntil("else", "endfor")
+	if err != nil {
+		return nil, err
+	}
+	output.body = body
+	if stop == "else" {
+		output.elseBody, _, err = parser.parseUntil("endfor")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+func (parser *liquidParser) parseTablerow(args string) (liquidNode, error) {
+	loop, err := parseLiquidLoop("tablerow", args)
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := parser.parseUntil("endtablerow")
+	if err != nil {
+		return nil, err
+	}
+	return liquidTablerowNode{loop: loop, body: body}, nil
+}
+
+func parseLiquidLoop(tag string, args string) (liquidLoop, error) {
+	fields := tokenizeLiquidExpression(args)
+	if len(fields) < 3 || fields[1] != "in" {
+		return liquidLoop{}, fmt.Errorf("liquid: invalid %s loop '%s'", tag, args)
+	}
+	collection, err := parseLiquidValue(fields[2])
+	if err != nil {
+		return liquidLoop{}, err
+	}
+	output := liquidLoop{variable: fields[0], collection: collection}
+	for i := 3; i < len(fields); i++ {
+		switch {
+		case fields[i] == "," && tag == "tablerow":
+		case fields[i] == "reversed" && tag == "for":
+			output.reversed = true
+		case fields[i] == "limit:" || fields[i] == "offset:" || (fields[i] == "cols:" && tag == "tablerow"):
+			if i+1 >= len(fields) {
+				return liquidLoop{}, fmt.Errorf("liquid: missing value for '%s' in %s loop", fields[i], tag)
+			}
+			value, err := parseLiquidValue(fields[i+1])
+			if err != nil {
+				return liquidLoop{}, err
+			}
+			switch fields[i] {
+			case "limit:":
+				output.limit = &value
+			case "offset:":
+				output.offset = &value
+			default:
+				output.cols = &value
+			}
+			i++
+		default:
+			return liquidLoop{}, fmt.Errorf("liquid: unknown %s loop option '%s'", tag, fields[i])
+		}
+	}
+	return output, nil
+}
+
+func parseLiquidCycle(args string) (liquidNode, error) {
+	tokens := tokenizeLiquidExpression(args)
+	output := liquidCycleNode{}
+	if len(tokens) > 1 && strings.HasSuffix(tokens[0], ":") {
+		group, err := parseLiquidValue(strings.TrimSuffix(tokens[0], ":"))
+		if err != nil {
+			return nil, err
+		}
+		output.group = &group
+		tokens = tokens[1:]
+	}
+	var keys []string
+	for _, token := range tokens {
+		if token == "," {
+			continue
+		}
+		value, err := parseLiquidValue(token)
+		if err != nil {
+			return nil, err
+		}
+		output.values = append(output.values, value)
+		keys = append(keys, token)
+	}
+	if len(output.values) == 0 {
+		return nil, fmt.Errorf("liquid: invalid cycle '%s'", args)
+	}
+	output.key = strings.Join(keys, ",")
+	return output, nil
+}
+
+// Expressions
+
+type liquidValue struct {
+	literal  any
+	path     []string
+	isPath   bool
+	isEmpty  bool // the 'empty' and 'blank' keywords
+	rangeMin *liquidValue
+	rangeMax *liquidValue
+}
+
+type liquidFilter struct {
+	name     string
+	args     []liquidValue
+	keywords map[string]liquidValue
+}
+
+type liquidExpression struct {
+	value   liquidValue
+	filters []liquidFilter
+}
+
+type liquidComparison struct {
+	left     liquidValue
+	operator string
+	right    *liquidValue
+}
+
+type liquidCondition struct {
+	comparison liquidComparison
+	connective string // "and" or "or", empty for the last comparison
+	next       *liquidCondition
+	negate     bool
+}
+
+// tokenizeLiquidExpression splits on whitespace while keeping quoted strings and ranges intact
+func tokenizeLiquidExpression(input string) []string {
+	var output []string
+	var current strings.Builder
+	var quote rune
+	depth := 0
+	flush := func() {
+		if current.Len() > 0 {
+			output = append(output, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range input {
+		switch {
+		case quote != 0:
+			current.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			current.WriteRune(r)
+		case r == '(' || r == '[':
+			depth++
+			current.WriteRune(r)
+		case r == ')' || r == ']':
+			depth--
+			current.WriteRune(r)
+		case depth == 0 && unicode.IsSpace(r):
+			flush()
+		case depth == 0 && (r == '|' || r == ','):
+			flush()
+			output = append(output, string(r))
+		case depth == 0 && r == ':':
+			current.WriteRune(r)
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return output
+}
+
+// splitLiquidList splits 'a, b or c' into its values
+func splitLiquidList(input string) []string {
+	var output []string
+	for _, token := range tokenizeLiquidExpression(input) {
+		if token != "," && token != "or" {
+			output = append(output, token)
+		}
+	}
+	return output
+}
+
+func parseLiquidExpression(input string) (liquidExpression, error) {
+	tokens := tokenizeLiquidExpression(input)
+	if len(tokens) == 0 {
+		return liquidExpression{}, fmt.Errorf("liquid: empty expression")
+	}
+	value, err := parseLiquidValue(tokens[0])
+	if err != nil {
+		return liquidExpression{}, err
+	}
+	output := liquidExpression{value: value}
+	i := 1
+	for i < len(tokens) {
+		if tokens[i] != "|" || i+1 >= len(tokens) {
+			return liquidExpression{}, fmt.Errorf("liquid: invalid expression '%s'", input)
+		}
+		filter := liquidFilter{name: strings.TrimSuffix(tokens[i+1], ":")}
+		i += 2
+		for i < len(tokens) && tokens[i] != "|" {
+			switch {
+			case tokens[i] == ",":
+				i++
+			case strings.HasSuffix(tokens[i], ":") && i+1 < len(tokens):
+				arg, err := parseLiquidValue(tokens[i+1])
+				if err != nil {
+					return liquidExpression{}, err
+				}
+				if filter.keywords == nil {
+					filter.keywords = map[string]liquidValue{}
+				}
+				filter.keywords[strings.TrimSuffix(tokens[i], ":")] = arg
+				i += 2
+			default:
+				arg, err := parseLiquidValue(tokens[i])
+				if err != nil {
+					return liquidExpression{}, err
+				}
+				filter.args = append(filter.args, arg)
+				i++
+			}
+		}
+		output.filters = append(output.filters, filter)
+	}
+	return output, nil
+}
+
+var liquidIntegerPattern = regexp.MustCompile(`^-?\d+$`)
+
+func parseLiquidValue(token string) (liquidValue, error) {
+	switch token {
+	case "nil", "null":
+		return liquidValue{}, nil
+	case "true":
+		return liquidValue{literal: true}, nil
+	case "false":
+		return liquidValue{literal: false}, nil
+	case "empty", "blank":
+		return liquidValue{isEmpty: true}, nil
+	}
+	if len(token) >= 2 && (token[0] == '"' || token[0] == '\'') && token[len(token)-1] == token[0] {
+		return liquidValue{literal: token[1 : len(token)-1]}, nil
+	}
+	if liquidIntegerPattern.MatchString(token) {
+		if integer, err := strconv.Atoi(token); err == nil {
+			return liquidValue{literal: integer}, nil
+		}
+	}
+	if number, err := strconv.ParseFloat(token, 64); err == nil {
+		return liquidValue{literal: number}, nil
+	}
+	if strings.HasPrefix(token, "(") && strings.HasSuffix(token, ")") {
+		low, high, ok := strings.Cut(token[1:len(token)-1], "..")
+		if !ok {
+			return liquidValue{}, fmt.Errorf("liquid: invalid range '%s'", token)
+		}
+		minimum, err := parseLiquidValue(strings.TrimSpace(low))
+		if err != nil {
+			return liquidValue{}, err
+		}
+		maximum, err := parseLiquidValue(strings.TrimSpace(high))
+		if err != nil {
+			return liquidValue{}, err
+		}
+		return liquidValue{rangeMin: &minimum, rangeMax: &maximum}, nil
+	}
+	path, err := parseLiquidPath(token)
+	if err != nil {
+		return liquidValue{}, err
+	}
+	return liquidValue{path: path, isPath: true}, nil
+}
+
+func parseLiquidPath(token string) ([]string, error) {
+	var output []string
+	for len(token) > 0 {
+		switch token[0] {
+		case '.':
+			token = token[1:]
+		case '[':
+			end := strings.Index(token, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("liquid: invalid variable '%s'", token)
+			}
+			output = append(output, strings.Trim(token[1:end], `"'`))
+			token = token[end+1:]
+		default:
+			end := strings.IndexAny(token, ".[")
+			if end < 0 {
+				end = len(token)
+			}
+			name := token[:end]
+			for _, r := range name {
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '?' {
+					return nil, fmt.Errorf("liquid: invalid variable '%s'", name)
+				}
+			}
+			output = append(output, name)
+			token = token[end:]
+		}
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("liquid: empty variable")
+	}
+	return output, nil
+}
+
+func parseLiquidCondition(input string) (*liquidCondition, error) {
+	tokens := tokenizeLiquidExpression(input)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("liquid: empty condition")
+	}
+	var head, tail *liquidCondition
+	for len(tokens) > 0 {
+		left, err := parseLiquidValue(tokens[0])
+		if err != nil {
+			return nil, err
+		}
+		condition := &liquidCondition{comparison: liquidComparison{left: left}}
+		tokens = tokens[1:]
+		if len(tokens) >= 2 && tokens[0] != "and" && tokens[0] != "or" {
+			right, err := parseLiquidValue(tokens[1])
+			if err != nil {
+				return nil, err
+			}
+			condition.comparison.operator = tokens[0]
+			condition.comparison.right = &right
+			tokens = tokens[2:]
+		}
+		if len(tokens) > 0 {
+			if tokens[0] != "and" && tokens[0] != "or" {
+				return nil, fmt.Errorf("liquid: invalid condition '%s'", input)
+			}
+			condition.connective = tokens[0]
+			tokens = tokens[1:]
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("liquid: invalid condition '%s'", input)
+			}
+		}
+		if head == nil {
+			head = condition
+		} else {
+			tail.next = condition
+		}
+		tail = condition
+	}
+	return head, nil
+}
+
+// Rendering
+
+type liquidRenderer struct {
+	scope    map[string]any
+	counters map[string]int // 'increment' and 'decrement' counters, kept apart from assigned variables
+	cycles   map[string]int
+}
+
+func (renderer *liquidRenderer) render(nodes []liquidNode, output *strings.Builder) error {
+	for _, node := range nodes {
+		if err := renderer.renderNode(node, output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (renderer *liquidRenderer) renderNode(node liquidNode, output *strings.Builder) error {
+	scope := renderer.scope
+	switch node := node.(type) {
+	case liquidTextNode:
+		output.WriteString(node.text)
+	case liquidOutputNode:
+		value, err := node.expression.evaluate(scope)
+		if err != nil {
+			return err
+		}
+		output.WriteString(liquidToString(value))
+	case liquidAssignNode:
+		value, err := node.expression.evaluate(scope)
+		if err != nil {
+			return err
+		}
+		scope[node.name] = value
+	case liquidCaptureNode:
+		var captured strings.Builder
+		if err := renderer.render(node.body, &captured); err != nil {
+			return err
+		}
+		scope[node.name] = captured.String()
+	case liquidIfNode:
+		for _, branch := range node.branches {
+			if branch.condition == nil || branch.condition.evaluate(scope) {
+				return renderer.render(branch.body, output)
+			}
+		}
+	case liquidCaseNode:
+		subject, err := node.subject.evaluate(scope)
+		if err != nil {
+			return err
+		}
+		for i, values := range node.whens {
+			for _, value := range values {
+				if liquidEqual(subject, value.resolve(scope)) {
+					return renderer.render(node.bodies[i], output)
+				}
+			}
+		}
+		return renderer.render(node.elseBody, output)
+	case liquidForNode:
+		return renderer.renderFor(node, output)
+	case liquidTablerowNode:
+		return renderer.renderTablerow(node, output)
+	case liquidInterruptNode:
+		return node.interrupt
+	case liquidCounterNode:
+		value := renderer.counters[node.name]
+		if node.increment {
+			output.WriteString(strconv.Itoa(value))
+			renderer.counters[node.name] = value + 1
+		} else {
+			renderer.counters[node.name] = value - 1
+			output.WriteString(strconv.Itoa(value - 1))
+		}
+	case liquidCycleNode:
+		key := node.key
+		if node.group != nil {
+			key = liquidToString(node.group.resolve(scope))
+		}
+		index := renderer.cycles[key] % len(node.values)
+		renderer.cycles[key] = index + 1
+		output.WriteString(liquidToString(node.values[index].resolve(scope)))
+	}
+	return nil
+}
+
+// items returns the collection of the loop after applying offset, limit and reversed
+func (loop liquidLoop) items(scope map[string]any) []any {
+	items := liquidToSlice(loop.collection.resolve(scope))
+	if loop.offset != nil {
+		offset := liquidToInt(loop.offset.resolve(scope))
+		items = items[min(max(offset, 0), len(items)):]
+	}
+	if loop.limit != nil {
+		limit := liquidToInt(loop.limit.resolve(scope))
+		items = items[:min(max(limit, 0), len(items))]
+	}
+	if loop.reversed {
+		reversed := make([]any, len(items))
+		for i, item := range items {
+			reversed[len(items)-1-i] = item
+		}
+		items = reversed
+	}
+	return items
+}
+
+// scoped sets 'values' while 'fn' runs and restores what they were afterwards
+func (renderer *liquidRenderer) scoped(values map[string]any, fn func() error) error {
+	type saved struct {
+		value   any
+		existed bool
+	}
+	previous := map[string]saved{}
+	for name := range values {
+		value, existed := renderer.scope[name]
+		previous[name] = saved{value: value, existed: existed}
+	}
+	err := fn()
+	for name, saved := range previous {
+		if saved.existed {
+			renderer.scope[name] = saved.value
+		} else {
+			delete(renderer.scope, name)
+		}
+	}
+	return err
+}
+
+func (renderer *liquidRenderer) renderFor(node liquidForNode, output *strings.Builder) error {
+	items := node.loop.items(renderer.scope)
+	if len(items) == 0 {
+		return renderer.render(node.elseBody, output)
+	}
+	parentLoop := renderer.scope["forloop"]
+	return renderer.scoped(map[string]any{node.loop.variable: nil, "forloop": nil}, func() error {
+		for i, item := range items {
+			renderer.scope[node.loop.variable] = item
+			renderer.scope["forloop"] = map[string]any{
+				"index":      i + 1,
+				"index0":     i,
+				"first":      i == 0,
+				"last":       i == len(items)-1,
+				"length":     len(items),
+				"rindex":     len(items) - i,
+				"rindex0":    len(items) - i - 1,
+				"parentloop": parentLoop,
+			}
+			err := renderer.render(node.body, output)
+			if errors.Is(err, liquidBreak) {
+				break
+			}
+			if err != nil && !errors.Is(err, liquidContinue) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (renderer *liquidRenderer) renderTablerow(node liquidTablerowNode, output *strings.Builder) error {
+	items := node.loop.items(renderer.scope)
+	cols := len(items)
+	if node.loop.cols != nil {
+		cols = liquidToInt(node.loop.cols.resolve(renderer.scope))
+	}
+	if cols <= 0 {
+		cols = max(len(items), 1)
+	}
+	output.WriteString("<tr class=\"row1\">\n")
+	err := renderer.scoped(map[string]any{node.loop.variable: nil, "tablerowloop": nil}, func() error {
+		for i, item := range items {
+			col, row := i%cols, i/cols
+			renderer.scope[node.loop.variable] = item
+			renderer.scope["tablerowloop"] = map[string]any{
+				"col":       col + 1,
+				"col0":      col,
+				"col_first": col == 0,
+				"col_last":  col == cols-1,
+				"row":       row + 1,
+				"index":     i + 1,
+				"index0":    i,
+				"first":     i == 0,
+				"last":      i == len(items)-1,
+				"length":    len(items),
+				"rindex":    len(items) - i,
+				"rindex0":   len(items) - i - 1,
+			}
+			fmt.Fprintf(output, "<td class=\"col%d\">", col+1)
+			err := renderer.render(node.body, output)
+			output.WriteString("</td>")
+			if col == cols-1 && i != len(items)-1 {
+				fmt.Fprintf(output, "</tr>\n<tr class=\"row%d\">", row+2)
+			}
+			if errors.Is(err, liquidBreak) {
+				break
+			}
+			if err != nil && !errors.Is(err, liquidContinue) {
+				return err
+			}
+		}
+		return nil
+	})
+	output.WriteString("</tr>\n")
+	return err
+}
+
+func (value liquidValue) resolve(scope map[string]any) any {
+	switch {
+	case value.isEmpty:
+		return liquidEmpty{}
+	case value.rangeMin != nil:
+		low := liquidToInt(value.rangeMin.resolve(scope))
+		high := liquidToInt(value.rangeMax.resolve(scope))
+		var output []any
+		for i := low; i <= high; i++ {
+			output = append(output, i)
+		}
+		return output
+	case !value.isPath:
+		return value.literal
+	}
+	var current any = scope
+	for _, segment := range value.path {
+		current = liquidLookup(current, segment)
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// liquidEmpty is the value of the 'empty' and 'blank' keywords
+type liquidEmpty struct{}
+
+func liquidLookup(value any, key string) any {
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Map:
+		if reflected.Type().Key().Kind() == reflect.String {
+			item := reflected.MapIndex(reflect.ValueOf(key).Convert(reflected.Type().Key()))
+			if item.IsValid() {
+				return item.Interface()
+			}
+		}
+		if key == "size" {
+			return reflected.Len()
+		}
+	case reflect.Slice, reflect.Array:
+		switch key {
+		case "size":
+			return reflected.Len()
+		case "first":
+			if reflected.Len() > 0 {
+				return reflected.Index(0).Interface()
+			}
+		case "last":
+			if reflected.Len() > 0 {
+				return reflected.Index(reflected.Len() - 1).Interface()
+			}
+		default:
+			index, err := strconv.Atoi(key)
+			if err != nil {
+				return nil
+			}
+			if index < 0 {
+				index += reflected.Len()
+			}
+			if index >= 0 && index < reflected.Len() {
+				return reflected.Index(index).Interface()
+			}
+		}
+	case reflect.String:
+		if key == "size" {
+			return len([]rune(reflected.String()))
+		}
+	}
+	return nil
+}
+
+func (expression liquidExpression) evaluate(scope map[string]any) (any, error) {
+	value := expression.value.resolve(scope)
+	for _, filter := range expression.filters {
+		args := make([]any, len(filter.args))
+		for i, arg := range filter.args {
+			args[i] = arg.resolve(scope)
+		}
+		keywords := make(map[string]any, len(filter.keywords))
+		for name, arg := range filter.keywords {
+			keywords[name] = arg.resolve(scope)
+		}
+		var err error
+		value, err = applyLiquidFilter(filter.name, value, args, keywords)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+func (condition *liquidCondition) evaluate(scope map[string]any) bool {
+	result := condition.comparison.evaluate(scope)
+	// Liquid evaluates 'and' and 'or' from right to left without precedence
+	if condition.next != nil {
+		rest := condition.next.evaluate(scope)
+		if condition.connective == "and" {
+			result = result && rest
+		} else {
+			result = result || rest
+		}
+	}
+	if condition.negate {
+		return !result
+	}
+	return result
+}
+
+func (comparison liquidComparison) evaluate(scope map[string]any) bool {
+	left := comparison.left.resolve(scope)
+	if comparison.right == nil {
+		return liquidTruthy(left)
+	}
+	right := comparison.right.resolve(scope)
+	switch comparison.operator {
+	case "==":
+		return liquidEqual(left, right)
+	case "!=", "<>":
+		return !liquidEqual(left, right)
+	case "<", ">", "<=", ">=":
+		order, ok := liquidCompare(left, right)
+		if !ok {
+			return false
+		}
+		switch comparison.operator {
+		case "<":
+			return order < 0
+		case ">":
+			return order > 0
+		case "<=":
+			return order <= 0
+		default:
+			return order >= 0
+		}
+	case "contains":
+		return liquidContains(left, right)
+	}
+	return false
+}
+
+func liquidTruthy(value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	}
+	return true
+}
+
+func liquidIsEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(reflected.String()) == ""
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return reflected.Len() == 0
+	}
+	return false
+}
+
+func liquidEqual(left any, right any) bool {
+	if _, ok := right.(liquidEmpty); ok {
+		return liquidIsEmpty(left)
+	}
+	if _, ok := left.(liquidEmpty); ok {
+		return liquidIsEmpty(right)
+	}
+	if leftNumber, ok := liquidAsNumber(left); ok {
+		if rightNumber, ok := liquidAsNumber(right); ok {
+			return leftNumber == rightNumber
+		}
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func liquidCompare(left any, right any) (int, bool) {
+	if leftNumber, ok := liquidAsNumber(left); ok {
+		if rightNumber, ok := liquidAsNumber(right); ok {
+			switch {
+			case leftNumber < rightNumber:
+				return -1, true
+			case leftNumber > rightNumber:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	leftString, leftOk := left.(string)
+	rightString, rightOk := right.(string)
+	if leftOk && rightOk {
+		return strings.Compare(leftString, rightString), true
+	}
+	return 0, false
+}
+
+func liquidContains(collection any, item any) bool {
+	if text, ok := collection.(string); ok {
+		return strings.Contains(text, liquidToString(item))
+	}
+	reflected := reflect.ValueOf(collection)
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < reflected.Len(); i++ {
+			if liquidEqual(reflected.Index(i).Interface(), item) {
+				return true
+			}
+		}
+	case reflect.Map:
+		return liquidLookup(collection, liquidToString(item)) != nil
+	}
+	return false
+}
+
+// liquidAsNumber is true for numeric values, strings are not numbers when comparing
+func liquidAsNumber(value any) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		number, err := value.Float64()
+		return number, err == nil
+	}
+	return 0, false
+}
+
+var (
+	liquidDecimalPattern = regexp.MustCompile(`^-?\d+\.\d+$`)
+	liquidLeadingInteger = regexp.MustCompile(`^[-+]?\d+`)
+)
+
+// liquidNumber converts 'value' to an int or a float64 the way Liquid's math filters do
+func liquidNumber(value any) any {
+	switch value := value.(type) {
+	case int:
+		return value
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case float32:
+		return float64(value)
+	case float64:
+		return value
+	case json.Number:
+		if integer, err := value.Int64(); err == nil {
+			return int(integer)
+		}
+		number, _ := value.Float64()
+		return number
+	case string:
+		text := strings.TrimSpace(value)
+		if liquidDecimalPattern.MatchString(text) {
+			number, _ := strconv.ParseFloat(text, 64)
+			return number
+		}
+		integer, _ := strconv.Atoi(strings.TrimPrefix(liquidLeadingInteger.FindString(text), "+"))
+		return integer
+	}
+	return 0
+}
+
+func liquidToInt(value any) int {
+	switch number := liquidNumber(value).(type) {
+	case int:
+		return number
+	case float64:
+		return int(number)
+	}
+	return 0
+}
+
+func liquidToFloat(value any) float64 {
+	switch number := liquidNumber(value).(type) {
+	case int:
+		return float64(number)
+	case float64:
+		return number
+	}
+	return 0
+}
+
+func liquidToString(value any) string {
+	switch value := value.(type) {
+	case nil, liquidEmpty:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case int:
+		return strconv.Itoa(value)
+	case int32, int64, json.Number:
+		return liquidToString(liquidNumber(value))
+	case float32:
+		return formatLiquidFloat(float64(value))
+	case float64:
+		return formatLiquidFloat(value)
+	}
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Array {
+		var output strings.Builder
+		for i := 0; i < reflected.Len(); i++ {
+			output.WriteString(liquidToString(reflected.Index(i).Interface()))
+		}
+		return output.String()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// formatLiquidFloat formats floats like Ruby, which always shows a fraction
+func formatLiquidFloat(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	}
+	if magnitude := math.Abs(value); magnitude >= 1e16 || (magnitude > 0 && magnitude < 1e-4) {
+		mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(value, 'e', -1, 64), "e")
+		if !strings.Contains(mantissa, ".") {
+			mantissa += ".0"
+		}
+		return mantissa + "e" + exponent
+	}
+	output := strconv.FormatFloat(value, 'f', -1, 64)
+	if !strings.Contains(output, ".") {
+		output += ".0"
+	}
+	return output
+}
+
+func liquidToSlice(value any) []any {
+	if value == nil {
+		return nil
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Array:
+		output := make([]any, reflected.Len())
+		for i := range output {
+			output[i] = reflected.Index(i).Interface()
+		}
+		return output
+	case reflect.Map:
+		keys := reflected.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		output := make([]any, len(keys))
+		for i, key := range keys {
+			output[i] = []any{key.Interface(), reflected.MapIndex(key).Interface()}
+		}
+		return output
+	}
+	return []any{value}
+}
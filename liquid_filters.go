@@ -0,0 +1,667 @@
+package opslevel
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/relvacode/iso8601"
+)
+
+type liquidFilterFunc func(input any, args []any, options map[string]any) (any, error)
+
+var liquidFilters map[string]liquidFilterFunc
+
+func init() {
+	stringFilter := func(fn func(string) string) liquidFilterFunc {
+		return func(input any, args []any, options map[string]any) (any, error) {
+			return fn(liquidToString(input)), nil
+		}
+	}
+	stringArgFilter := func(count int, fn func(string, []string) string) liquidFilterFunc {
+		return func(input any, args []any, options map[string]any) (any, error) {
+			if len(args) != count {
+				return nil, fmt.Errorf("expects %d argument(s)", count)
+			}
+			values := make([]string, len(args))
+			for i, arg := range args {
+				values[i] = liquidToString(arg)
+			}
+			return fn(liquidToString(input), values), nil
+		}
+	}
+	mathFilter := func(integers func(int, int) (any, error), floats func(float64, float64) (any, error)) liquidFilterFunc {
+		return func(input any, args []any, options map[string]any) (any, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expects 1 argument")
+			}
+			left, right := liquidNumber(input), liquidNumber(args[0])
+			leftInteger, leftOk := left.(int)
+			rightInteger, rightOk := right.(int)
+			if leftOk && rightOk {
+				return integers(leftInteger, rightInteger)
+			}
+			return floats(liquidToFloat(left), liquidToFloat(right))
+		}
+	}
+	roundingFilter := func(fn func(float64) float64) liquidFilterFunc {
+		return func(input any, args []any, options map[string]any) (any, error) {
+			if integer, ok := liquidNumber(input).(int); ok {
+				return integer, nil
+			}
+			return int(fn(liquidToFloat(input))), nil
+		}
+	}
+	arrayFilter := func(fn func([]any, []any) ([]any, error)) liquidFilterFunc {
+		return func(input any, args []any, options map[string]any) (any, error) {
+			return fn(liquidToSlice(input), args)
+		}
+	}
+	liquidFilters = map[string]liquidFilterFunc{
+		"default": func(input any, args []any, options map[string]any) (any, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expects 1 argument")
+			}
+			if input == false && liquidTruthy(options["allow_false"]) {
+				return input, nil
+			}
+			if !liquidTruthy(input) || liquidIsEmpty(input) {
+				return args[0], nil
+			}
+			return input, nil
+		},
+
+		// String filters
+		"upcase":         stringFilter(strings.ToUpper),
+		"downcase":       stringFilter(strings.ToLower),
+		"capitalize":     stringFilter(capitalizeLiquid),
+		"strip":          stringFilter(strings.TrimSpace),
+		"lstrip":         stringFilter(func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) }),
+		"rstrip":         stringFilter(func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) }),
+		"strip_newlines": stringFilter(func(s string) string { return strings.NewReplacer("\r\n", "", "\n", "").Replace(s) }),
+		"newline_to_br":  stringFilter(func(s string) string { return liquidNewlinePattern.ReplaceAllString(s, "<br />\n") }),
+		"strip_html":     stringFilter(func(s string) string { return liquidHTMLPattern.ReplaceAllString(s, "") }),
+		"escape":         stringFilter(escapeLiquid),
+		"escape_once":    stringFilter(func(s string) string { return escapeLiquid(html.UnescapeString(s)) }),
+		"url_encode":     stringFilter(url.QueryEscape),
+		"url_decode": func(input any, args []any, options map[string]any) (any, error) {
+			return url.QueryUnescape(liquidToString(input))
+		},
+		"base64_encode":          stringFilter(func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }),
+		"base64_url_safe_encode": stringFilter(func(s string) string { return base64.URLEncoding.EncodeToString([]byte(s)) }),
+		"base64_decode": func(input any, args []any, options map[string]any) (any, error) {
+			data, err := base64.StdEncoding.DecodeString(liquidToString(input))
+			return string(data), err
+		},
+		"base64_url_safe_decode": func(input any, args []any, options map[string]any) (any, error) {
+			data, err := base64.URLEncoding.DecodeString(liquidToString(input))
+			return string(data), err
+		},
+		"append":        stringArgFilter(1, func(s string, args []string) string { return s + args[0] }),
+		"prepend":       stringArgFilter(1, func(s string, args []string) string { return args[0] + s }),
+		"remove":        stringArgFilter(1, func(s string, args []string) string { return strings.ReplaceAll(s, args[0], "") }),
+		"remove_first":  stringArgFilter(1, func(s string, args []string) string { return strings.Replace(s, args[0], "", 1) }),
+		"remove_last":   stringArgFilter(1, func(s string, args []string) string { return replaceLastLiquid(s, args[0], "") }),
+		"replace":       stringArgFilter(2, func(s string, args []string) string { return strings.ReplaceAll(s, args[0], args[1]) }),
+		"replace_first": stringArgFilter(2, func(s string, args []string) string { return strings.Replace(s, args[0], args[1], 1) }),
+		"replace_last":  stringArgFilter(2, func(s string, args []string) string { return replaceLastLiquid(s, args[0], args[1]) }),
+		"split": func(input any, args []any, options map[string]any) (any, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expects 1 argument")
+			}
+			return splitLiquid(liquidToString(input), liquidToString(args[0])), nil
+		},
+		"truncate": func(input any, args []any, options map[string]any) (any, error) {
+			length, ellipsis := 50, "..."
+			if len(args) > 0 {
+				length = liquidToInt(args[0])
+			}
+			if len(args) > 1 {
+				ellipsis = liquidToString(args[1])
+			}
+			runes := []rune(liquidToString(input))
+			if len(runes) <= length {
+				return string(runes), nil
+			}
+			keep := max(length-len([]rune(ellipsis)), 0)
+			return string(runes[:keep]) + ellipsis, nil
+		},
+		"truncatewords": func(input any, args []any, options map[string]any) (any, error) {
+			length, ellipsis := 15, "..."
+			if len(args) > 0 {
+				length = liquidToInt(args[0])
+			}
+			if len(args) > 1 {
+				ellipsis = liquidToString(args[1])
+			}
+			text := liquidToString(input)
+			words := strings.Fields(text)
+			length = max(length, 1)
+			if len(words) <= length {
+				return text, nil
+			}
+			return strings.Join(words[:length], " ") + ellipsis, nil
+		},
+		"slice": func(input any, args []any, options map[string]any) (any, error) {
+			if len(args) < 1 || len(args) > 2 {
+				return nil, errors.New("expects 1 or 2 arguments")
+			}
+			length := 1
+			if len(args) == 2 {
+				length = liquidToInt(args[1])
+			}
+			if text, ok := input.(string); ok {
+				runes := []rune(text)
+				start, end := liquidSliceBounds(len(runes), liquidToInt(args[0]), length)
+				return string(runes[start:end]), nil
+			}
+			items := liquidToSlice(input)
+			start, end := liquidSliceBounds(len(items), liquidToInt(args[0]), length)
+			return items[start:end], nil
+		},
+		"json": func(input any, args []any, options map[string]any) (any, error) {
+			if _, ok := input.(liquidEmpty); ok {
+				input = nil
+			}
+			data, err := json.Marshal(input)
+			return string(data), err
+		},
+
+		// Math filters
+		"plus": mathFilter(
+			func(a, b int) (any, error) { return a + b, nil },
+			func(a, b float64) (any, error) { return a + b, nil },
+		),
+		"minus": mathFilter(
+			func(a, b int) (any, error) { return a - b, nil },
+			func(a, b float64) (any, error) { return a - b, nil },
+		),
+		"times": mathFilter(
+			func(a, b int) (any, error) { return a * b, nil },
+			func(a, b float64) (any, error) { return a * b, nil },
+		),
+		"divided_by": mathFilter(
+			func(a, b int) (any, error) {
+				if b == 0 {
+					return nil, errors.New("divided by 0")
+				}
+				return floorDivLiquid(a, b), nil
+			},
+			func(a, b float64) (any, error) { return a / b, nil },
+		),
+		"modulo": mathFilter(
+			func(a, b int) (any, error) {
+				if b == 0 {
+					return nil, errors.New("divided by 0")
+				}
+				return a - b*floorDivLiquid(a, b), nil
+			},
+			func(a, b float64) (any, error) { return a - b*math.Floor(a/b), nil },
+		),
+		"at_least": mathFilter(
+			func(a, b int) (any, error) { return max(a, b), nil },
+			func(a, b float64) (any, error) { return math.Max(a, b), nil },
+		),
+		"at_most": mathFilter(
+			func(a, b int) (any, error) { return min(a, b), nil },
+			func(a, b float64) (any, error) { return math.Min(a, b), nil },
+		),
+		"abs": func(input any, args []any, options map[string]any) (any, error) {
+			switch number := liquidNumber(input).(type) {
+			case int:
+				return max(number, -number), nil
+			case float64:
+				return math.Abs(number), nil
+			}
+			return 0, nil
+		},
+		"ceil":  roundingFilter(math.Ceil),
+		"floor": roundingFilter(math.Floor),
+		"round": func(input any, args []any, options map[string]any) (any, error) {
+			places := 0
+			if len(args) > 0 {
+				places = liquidToInt(args[0])
+			}
+			if integer, ok := liquidNumber(input).(int); ok {
+				return integer, nil
+			}
+			number := liquidToFloat(input)
+			if places <= 0 {
+				return int(math.Round(number)), nil
+			}
+			scale := math.Pow(10, float64(places))
+			return math.Round(number*scale) / scale, nil
+		},
+
+		// Array filters
+		"size": func(input any, args []any, options map[string]any) (any, error) {
+			if size := liquidLookup(input, "size"); size != nil {
+				return size, nil
+			}
+			return 0, nil
+		},
+		"first": func(input any, args []any, options map[string]any) (any, error) {
+			return liquidLookup(input, "first"), nil
+		},
+		"last": func(input any, args []any, options map[string]any) (any, error) {
+			return liquidLookup(input, "last"), nil
+		},
+		"join": func(input any, args []any, options map[string]any) (any, error) {
+			separator := " "
+			if len(args) > 0 {
+				separator = liquidToString(args[0])
+			}
+			var parts []string
+			for _, item := range liquidToSlice(input) {
+				parts = append(parts, liquidToString(item))
+			}
+			return strings.Join(parts, separator), nil
+		},
+		"concat": arrayFilter(func(items []any, args []any) ([]any, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expects 1 argument")
+			}
+			return append(append([]any{}, items...), liquidToSlice(args[0])...), nil
+		}),
+		"reverse": arrayFilter(func(items []any, args []any) ([]any, error) {
+			output := make([]any, len(items))
+			for i, item := range items {
+				output[len(items)-1-i] = item
+			}
+			return output, nil
+		}),
+		"compact": arrayFilter(func(items []any, args []any) ([]any, error) {
+			output := []any{}
+			for _, item := range items {
+				if len(args) > 0 {
+					if liquidLookup(item, liquidToString(args[0])) == nil {
+						continue
+					}
+				} else if item == nil {
+					continue
+				}
+				output = append(output, item)
+			}
+			return output, nil
+		}),
+		"uniq": arrayFilter(func(items []any, args []any) ([]any, error) {
+			output := []any{}
+			for _, item := range items {
+				key := item
+				if len(args) > 0 {
+					key = liquidLookup(item, liquidToString(args[0]))
+				}
+				duplicate := false
+				for _, existing := range output {
+					existingKey := existing
+					if len(args) > 0 {
+						existingKey = liquidLookup(existing, liquidToString(args[0]))
+					}
+					if liquidEqual(existingKey, key) {
+						duplicate = true
+						break
+					}
+				}
+				if !duplicate {
+					output = append(output, item)
+				}
+			}
+			return output, nil
+		}),
+		"map": arrayFilter(func(items []any, args []any) ([]any, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expects 1 argument")
+			}
+			output := make([]any, len(items))
+			for i, item := range items {
+				output[i] = liquidLookup(item, liquidToString(args[0]))
+			}
+			return output, nil
+		}),
+		"where": arrayFilter(func(items []any, args []any) ([]any, error) {
+			if len(args) < 1 || len(args) > 2 {
+				return nil, errors.New("expects 1 or 2 arguments")
+			}
+			output := []any{}
+			for _, item := range items {
+				value := liquidLookup(item, liquidToString(args[0]))
+				if (len(args) == 1 && liquidTruthy(value)) || (len(args) == 2 && liquidEqual(value, args[1])) {
+					output = append(output, item)
+				}
+			}
+			return output, nil
+		}),
+		"sort":         sortLiquidFilter(false),
+		"sort_natural": sortLiquidFilter(true),
+		"sum": func(input any, args []any, options map[string]any) (any, error) {
+			var integers int
+			var floats float64
+			isFloat := false
+			for _, item := range liquidToSlice(input) {
+				if len(args) > 0 {
+					item = liquidLookup(item, liquidToString(args[0]))
+				}
+				if _, ok := item.(bool); ok || item == nil {
+					continue
+				}
+				switch number := liquidNumber(item).(type) {
+				case int:
+					integers += number
+				case float64:
+					floats += number
+					isFloat = true
+				}
+			}
+			if isFloat {
+				return floats + float64(integers), nil
+			}
+			return integers, nil
+		},
+
+		"date": func(input any, args []any, options map[string]any) (any, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expects 1 argument")
+			}
+			format := liquidToString(args[0])
+			date, ok := parseLiquidDate(input)
+			if !ok || format == "" {
+				return input, nil
+			}
+			return strftimeLiquid(date, format), nil
+		},
+	}
+	liquidFilters["jsonify"] = liquidFilters["json"]
+}
+
+func applyLiquidFilter(name string, input any, args []any, options map[string]any) (any, error) {
+	filter, ok := liquidFilters[name]
+	if !ok {
+		return nil, fmt.Errorf("liquid: unknown filter '%s'", name)
+	}
+	output, err := filter(input, args, options)
+	if err != nil {
+		return nil, fmt.Errorf("liquid: filter '%s' %w", name, err)
+	}
+	return output, nil
+}
+
+var (
+	liquidNewlinePattern = regexp.MustCompile(`\r?\n`)
+	liquidHTMLPattern    = regexp.MustCompile(`(?s)<script.*?</script>|<!--.*?-->|<style.*?</style>|<.*?>`)
+)
+
+func capitalizeLiquid(value string) string {
+	runes := []rune(value)
+	if len(runes) == 0 {
+		return value
+	}
+	return string(unicode.ToUpper(runes[0])) + strings.ToLower(string(runes[1:]))
+}
+
+// escapeLiquid escapes like Ruby's CGI.escapeHTML
+func escapeLiquid(value string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;").Replace(value)
+}
+
+func replaceLastLiquid(value string, old string, replacement string) string {
+	index := strings.LastIndex(value, old)
+	if index < 0 {
+		return value
+	}
+	return value[:index] + replacement + value[index+len(old):]
+}
+
+// splitLiquid splits like Ruby's String#split, a single space splits on runs of whitespace and
+// trailing empty strings are dropped
+func splitLiquid(value string, separator string) []any {
+	var parts []string
+	switch separator {
+	case " ":
+		parts = strings.Fields(value)
+	case "":
+		for _, r := range value {
+			parts = append(parts, string(r))
+		}
+	default:
+		parts = strings.Split(value, separator)
+	}
+	for len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	output := make([]any, len(parts))
+	for i, part := range parts {
+		output[i] = part
+	}
+	return output
+}
+
+func liquidSliceBounds(size int, start int, length int) (int, int) {
+	if start < 0 {
+		start += size
+	}
+	if start < 0 || start > size || length < 0 {
+		return 0, 0
+	}
+	return start, min(start+length, size)
+}
+
+func floorDivLiquid(a int, b int) int {
+	quotient := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		quotient--
+	}
+	return quotient
+}
+
+// sortLiquidFilter sorts numbers before strings, 'natural' sorts strings case insensitively
+func sortLiquidFilter(natural bool) liquidFilterFunc {
+	return func(input any, args []any, options map[string]any) (any, error) {
+		items := liquidToSlice(input)
+		output := append([]any{}, items...)
+		key := func(item any) any {
+			if len(args) > 0 {
+				item = liquidLookup(item, liquidToString(args[0]))
+			}
+			if text, ok := item.(string); ok && natural {
+				return strings.ToLower(text)
+			}
+			return item
+		}
+		sort.SliceStable(output, func(i, j int) bool {
+			left, right := key(output[i]), key(output[j])
+			if left == nil || right == nil {
+				return right == nil && left != nil
+			}
+			if order, ok := liquidCompare(left, right); ok {
+				return order < 0
+			}
+			_, leftNumber := liquidAsNumber(left)
+			_, rightNumber := liquidAsNumber(right)
+			return leftNumber && !rightNumber
+		})
+		return output, nil
+	}
+}
+
+var liquidDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	time.UnixDate,
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// parseLiquidDate accepts 'now', 'today', unix timestamps and the common date formats
+func parseLiquidDate(input any) (time.Time, bool) {
+	switch value := input.(type) {
+	case time.Time:
+		return value, true
+	case iso8601.Time:
+		return value.Time, true
+	case int, int32, int64, float32, float64, json.Number:
+		seconds := liquidToFloat(value)
+		return time.Unix(int64(seconds), 0).UTC(), true
+	case string:
+		text := strings.TrimSpace(value)
+		switch strings.ToLower(text) {
+		case "now", "today":
+			return time.Now(), true
+		case "":
+			return time.Time{}, false
+		}
+		if liquidIntegerPattern.MatchString(text) {
+			seconds, err := strconv.ParseInt(text, 10, 64)
+			return time.Unix(seconds, 0).UTC(), err == nil
+		}
+		if date, err := iso8601.ParseString(text); err == nil {
+			return date, true
+		}
+		for _, layout := range liquidDateLayouts {
+			if date, err := time.Parse(layout, text); err == nil {
+				return date, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// strftimeLiquid formats 'date' with Ruby's strftime directives and its '-', '_', '0' and '^' flags
+func strftimeLiquid(date time.Time, format string) string {
+	var output strings.Builder
+	runes := []rune(format)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' || i+1 >= len(runes) {
+			output.WriteRune(runes[i])
+			continue
+		}
+		start := i
+		i++
+		flag := rune(0)
+		for i < len(runes) && strings.ContainsRune("-_0^", runes[i]) {
+			flag = runes[i]
+			i++
+		}
+		if i >= len(runes) {
+			output.WriteString(string(runes[start:]))
+			break
+		}
+		number := func(value int, width int, padding rune) string {
+			switch flag {
+			case '-':
+				return strconv.Itoa(value)
+			case '_':
+				padding = ' '
+			case '0':
+				padding = '0'
+			}
+			text := strconv.Itoa(value)
+			for len(text) < width {
+				text = string(padding) + text
+			}
+			return text
+		}
+		text := func(value string) string {
+			if flag == '^' {
+				return strings.ToUpper(value)
+			}
+			return value
+		}
+		hour12 := date.Hour() % 12
+		if hour12 == 0 {
+			hour12 = 12
+		}
+		switch runes[i] {
+		case 'Y':
+			output.WriteString(number(date.Year(), 0, '0'))
+		case 'C':
+			output.WriteString(number(date.Year()/100, 2, '0'))
+		case 'y':
+			output.WriteString(number(date.Year()%100, 2, '0'))
+		case 'm':
+			output.WriteString(number(int(date.Month()), 2, '0'))
+		case 'B':
+			output.WriteString(text(date.Month().String()))
+		case 'b', 'h':
+			output.WriteString(text(date.Month().String()[:3]))
+		case 'd':
+			output.WriteString(number(date.Day(), 2, '0'))
+		case 'e':
+			output.WriteString(number(date.Day(), 2, ' '))
+		case 'j':
+			output.WriteString(number(date.YearDay(), 3, '0'))
+		case 'H':
+			output.WriteString(number(date.Hour(), 2, '0'))
+		case 'k':
+			output.WriteString(number(date.Hour(), 2, ' '))
+		case 'I':
+			output.WriteString(number(hour12, 2, '0'))
+		case 'l':
+			output.WriteString(number(hour12, 2, ' '))
+		case 'M':
+			output.WriteString(number(date.Minute(), 2, '0'))
+		case 'S':
+			output.WriteString(number(date.Second(), 2, '0'))
+		case 'L':
+			output.WriteString(fmt.Sprintf("%03d", date.Nanosecond()/int(time.Millisecond)))
+		case 'N':
+			output.WriteString(fmt.Sprintf("%09d", date.Nanosecond()))
+		case 'p':
+			output.WriteString(date.Format("PM"))
+		case 'P':
+			output.WriteString(date.Format("pm"))
+		case 'A':
+			output.WriteString(text(date.Weekday().String()))
+		case 'a':
+			output.WriteString(text(date.Weekday().String()[:3]))
+		case 'u':
+			weekday := int(date.Weekday())
+			if weekday == 0 {
+				weekday = 7
+			}
+			output.WriteString(strconv.Itoa(weekday))
+		case 'w':
+			output.WriteString(strconv.Itoa(int(date.Weekday())))
+		case 'z':
+			output.WriteString(date.Format("-0700"))
+		case 'Z':
+			output.WriteString(date.Format("MST"))
+		case 's':
+			output.WriteString(strconv.FormatInt(date.Unix(), 10))
+		case 'F':
+			output.WriteString(strftimeLiquid(date, "%Y-%m-%d"))
+		case 'D', 'x':
+			output.WriteString(strftimeLiquid(date, "%m/%d/%y"))
+		case 'T', 'X':
+			output.WriteString(strftimeLiquid(date, "%H:%M:%S"))
+		case 'R':
+			output.WriteString(strftimeLiquid(date, "%H:%M"))
+		case 'r':
+			output.WriteString(strftimeLiquid(date, "%I:%M:%S %p"))
+		case 'c':
+			output.WriteString(strftimeLiquid(date, "%a %b %e %H:%M:%S %Y"))
+		case '%':
+			output.WriteRune('%')
+		default:
+			output.WriteString(string(runes[start : i+1]))
+		}
+	}
+	return output.String()
+}
@@ -0,0 +1,205 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestRenderLiquid(t *testing.T) {
+	variables := map[string]any{
+		"service": map[string]any{
+			"name":    "Catalog API",
+			"aliases": []any{"catalog_api", "catalog"},
+			"tier":    map[string]any{"index": 1},
+		},
+		"manualInputs": map[string]any{"environment": "staging", "count": "3"},
+		"response":     map[string]any{"status": 200, "body": map[string]any{"id": "abc"}},
+		"payload":      map[string]any{"passed": true, "items": []any{}},
+	}
+	testcases := map[string]struct {
+		template string
+		expected string
+	}{
+		"text":             {`hello`, `hello`},
+		"variable":         {`{{ service.name }}`, `Catalog API`},
+		"bracket access":   {`{{ service["aliases"][1] }}`, `catalog`},
+		"missing variable": {`[{{ service.owner.name }}]`, `[]`},
+		"size and first":   {`{{ service.aliases.size }} {{ service.aliases.first }}`, `2 catalog_api`},
+		"filters":          {`{{ service.name | downcase | replace: " ", "-" | append: "!" }}`, `catalog-api!`},
+		"default":          {`{{ manualInputs.region | default: "us-east-1" }}`, `us-east-1`},
+		"join":             {`{{ service.aliases | join: ", " }}`, `catalog_api, catalog`},
+		"json":             {`{{ response.body | json }}`, `{"id":"abc"}`},
+		"number":           {`{{ response.status }} {{ manualInputs.count | plus: 2 }}`, `200 5`},
+		"if":               {`{% if response.status == 200 %}ok{% else %}failed{% endif %}`, `ok`},
+		"elsif":            {`{% if service.tier.index > 2 %}low{% elsif service.tier.index == 1 %}high{% endif %}`, `high`},
+		"unless":           {`{% unless payload.passed %}failed{% endunless %}`, ``},
+		"and or":           {`{% if payload.passed and manualInputs.environment == "prod" or true %}yes{% endif %}`, `yes`},
+		"contains":         {`{% if service.aliases contains "catalog" %}yes{% endif %}`, `yes`},
+		"empty":            {`{% if payload.items == empty %}none{% endif %}`, `none`},
+		"for":              {`{% for alias in service.aliases %}{{ forloop.index }}:{{ alias }}{% unless forloop.last %},{% endunless %}{% endfor %}`, `1:catalog_api,2:catalog`},
+		"for else":         {`{% for item in payload.items %}{{ item }}{% else %}nothing{% endfor %}`, `nothing`},
+		"for range":        {`{% for i in (1..3) reversed %}{{ i }}{% endfor %}`, `321`},
+		"case":             {`{% case manualInputs.environment %}{% when "prod" %}P{% when "staging", "dev" %}S{% else %}?{% endcase %}`, `S`},
+		"assign":           {`{% assign env = manualInputs.environment | upcase %}{{ env }}`, `STAGING`},
+		"capture":          {`{% capture title %}{{ service.name }} in {{ manualInputs.environment }}{% endcapture %}{{ title }}`, `Catalog API in staging`},
+		"comment":          {`a{% comment %}{{ hidden }}{% endcomment %}b`, `ab`},
+		"raw":              {`{% raw %}{{ service.name }}{% endraw %}`, `{{ service.name }}`},
+		"whitespace":       {"a  {%- if true -%}  b  {%- endif -%}  c", `abc`},
+		"lone braces":      {`{"key": "{{ service.name }}"}`, `{"key": "Catalog API"}`},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result, err := ol.RenderLiquid(tc.template, variables)
+			// Assert
+			autopilot.Ok(t, err)
+			autopilot.Equals(t, tc.expected, result)
+		})
+	}
+}
+
+// TestRenderLiquidStandard compares against the output of the Liquid reference implementation
+func TestRenderLiquidStandard(t *testing.T) {
+	variables := map[string]any{
+		"products": []any{
+			map[string]any{"title": "Vacuum", "type": "kitchen", "price": 100, "available": true},
+			map[string]any{"title": "Spatula", "type": "kitchen", "price": 5, "available": false},
+			map[string]any{"title": "Television", "type": "living", "price": 400, "available": true},
+			map[string]any{"title": "Gift card", "price": nil, "available": true},
+		},
+	}
+	testcases := map[string]struct {
+		template string
+		expected string
+	}{
+		"integer division":          {`{{ 10 | divided_by: 3 }}`, `3`},
+		"float division":            {`{{ 10 | divided_by: 3.0 }}`, `3.3333333333333335`},
+		"negative integer division": {`{{ -7 | divided_by: 2 }}`, `-4`},
+		"modulo":                    {`{{ 24 | modulo: 7 }} {{ -7 | modulo: 3 }}`, `3 2`},
+		"plus":                      {`{{ 4 | plus: 2 }} {{ 183.357 | plus: 12 }}`, `6 195.357`},
+		"times":                     {`{{ 3 | times: 1.15 }} {{ 1.5 | times: 2 }}`, `3.4499999999999997 3.0`},
+		"float output":              {`{{ 2.0 }} {{ 0.1 | plus: 0.2 }}`, `2.0 0.30000000000000004`},
+		"string math":               {`{{ "16" | divided_by: 4 }} {{ "3.5" | ceil }}`, `4 4`},
+		"round":                     {`{{ 1.2 | round }} {{ 2.7 | round }} {{ 183.357 | round: 2 }}`, `1 3 183.36`},
+		"ceil floor":                {`{{ 1.2 | ceil }} {{ 1.8 | floor }} {{ 5 | floor }}`, `2 1 5`},
+		"abs":                       {`{{ -17 | abs }} {{ "-19.86" | abs }}`, `17 19.86`},
+		"at least at most":          {`{{ 4 | at_least: 5 }} {{ 4 | at_most: 3 }}`, `5 3`},
+		"truncate":                  {`{{ "Ground control to Major Tom." | truncate: 20 }}`, `Ground control to...`},
+		"truncate ellipsis":         {`{{ "Ground control to Major Tom." | truncate: 25, ", and so on" }}`, `Ground control, and so on`},
+		"truncate no ellipsis":      {`{{ "Ground control to Major Tom." | truncate: 20, "" }}`, `Ground control to Ma`},
+		"truncatewords":             {`{{ "Ground control to Major Tom." | truncatewords: 3 }}`, `Ground control to...`},
+		"truncatewords ellipsis":    {`{{ "Ground control to Major Tom." | truncatewords: 3, "--" }}`, `Ground control to--`},
+		"date":                      {`{{ "2015-03-25T10:00:00Z" | date: "%a, %b %d, %y" }}`, `Wed, Mar 25, 15`},
+		"date long":                 {`{{ "March 14, 2016" | date: "%B %-d, %Y %H:%M" }}`, `March 14, 2016 00:00`},
+		"date unix":                 {`{{ 1152098955 | date: "%m/%d/%Y" }}`, `07/05/2006`},
+		"date unparseable":          {`{{ "not a date" | date: "%Y" }}`, `not a date`},
+		"break":                     {`{% for i in (1..5) %}{% if i == 4 %}{% break %}{% endif %}{{ i }}{% endfor %}`, `123`},
+		"continue":                  {`{% for i in (1..5) %}{% if i == 3 %}{% continue %}{% endif %}{{ i }}{% endfor %}`, `1245`},
+		"parentloop":                {`{% for a in (1..2) %}{% for b in (1..2) %}{{ forloop.parentloop.index }}{{ b }} {% endfor %}{% endfor %}`, `11 12 21 22 `},
+		"for limit offset":          {`{% for i in (1..6) limit:2 offset:3 %}{{ i }}{% endfor %}`, `45`},
+		"cycle":                     {`{% for i in (1..4) %}{% cycle "a", "b", "c" %}{% endfor %}`, `abca`},
+		"increment decrement":       {`{% increment counter %}{% increment counter %}{% decrement other %}`, `01-1`},
+		"echo":                      {`{% echo "hi" | upcase %}`, `HI`},
+		"liquid tag":                {"{% liquid\n assign x = 2\n echo x | times: 3\n%}", `6`},
+		"inline comment":            {`a{% # not shown %}b`, `ab`},
+		"tablerow":                  {`{% tablerow i in (1..3) cols:2 %}{{ i }}{% endtablerow %}`, "<tr class=\"row1\">\n<td class=\"col1\">1</td><td class=\"col2\">2</td></tr>\n<tr class=\"row2\"><td class=\"col1\">3</td></tr>\n"},
+		"slice":                     {`{{ "Liquid" | slice: 0 }} {{ "Liquid" | slice: 2, 5 }} {{ "Liquid" | slice: -3, 2 }}`, `L quid ui`},
+		"strip html":                {`{{ "Have <em>you</em> read <strong>Ulysses</strong>?" | strip_html }}`, `Have you read Ulysses?`},
+		"escape":                    {`{{ "Tetsuro Takara's <b>" | escape }}`, `Tetsuro Takara&#39;s &lt;b&gt;`},
+		"escape once":               {`{{ "1 &lt; 2 & 3" | escape_once }}`, `1 &lt; 2 &amp; 3`},
+		"newline to br":             {"{% capture text %}a\nb{% endcapture %}{{ text | newline_to_br }}", "a<br />\nb"},
+		"replace first last":        {`{{ "my my my" | replace_first: "my", "your" }} {{ "my my my" | remove_last: "my" }}`, `your my my my my `},
+		"split whitespace":          {`{{ "  a  b " | split: " " | join: "," }}`, `a,b`},
+		"reverse":                   {`{{ "apples, oranges, peaches" | split: ", " | reverse | join: ", " }}`, `peaches, oranges, apples`},
+		"sort":                      {`{{ "zebra, octopus, giraffe, Sally Snake" | split: ", " | sort | join: ", " }}`, `Sally Snake, giraffe, octopus, zebra`},
+		"sort natural":              {`{{ "zebra, octopus, giraffe, Sally Snake" | split: ", " | sort_natural | join: ", " }}`, `giraffe, octopus, Sally Snake, zebra`},
+		"uniq":                      {`{{ "ants, bugs, bees, bugs, ants" | split: ", " | uniq | join: ", " }}`, `ants, bugs, bees`},
+		"concat":                    {`{{ "a,b" | split: "," | concat: products | size }}`, `6`},
+		"map":                       {`{{ products | map: "title" | join: ", " }}`, `Vacuum, Spatula, Television, Gift card`},
+		"where":                     {`{{ products | where: "type", "kitchen" | map: "title" | join: ", " }}`, `Vacuum, Spatula`},
+		"where truthy":              {`{{ products | where: "available" | size }}`, `3`},
+		"compact":                   {`{{ products | map: "type" | compact | join: ", " }}`, `kitchen, kitchen, living`},
+		"sort by property":          {`{{ products | sort: "price" | map: "title" | join: ", " }}`, `Spatula, Vacuum, Television, Gift card`},
+		"sum":                       {`{{ products | sum: "price" }} {{ "1.5,2" | split: "," | sum }}`, `505 3.5`},
+		"default allow false":       {`{{ false | default: "x", allow_false: true }} {{ false | default: "x" }}`, `false x`},
+		"base64":                    {`{{ "one two" | base64_encode }} {{ "b25lIHR3bw==" | base64_decode }}`, `b25lIHR3bw== one two`},
+		"url":                       {`{{ "a b&c" | url_encode }} {{ "a+b%26c" | url_decode }}`, `a+b%26c a b&c`},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result, err := ol.RenderLiquid(tc.template, variables)
+			// Assert
+			autopilot.Ok(t, err)
+			autopilot.Equals(t, tc.expected, result)
+		})
+	}
+}
+
+func TestRenderLiquidWithContextKeepsIntegers(t *testing.T) {
+	// Arrange
+	liquidContext := ol.LiquidContext{Response: `{"count": 10, "ratio": 2.5}`}
+	// Act
+	result, err := ol.RenderLiquidWithContext(`{{ response.count | divided_by: 3 }} {{ response.ratio | times: 2 }}`, liquidContext)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `3 5.0`, result)
+}
+
+func TestRenderLiquidErrors(t *testing.T) {
+	testcases := map[string]string{
+		"unclosed output": `{{ service.name`,
+		"unclosed block":  `{% if true %}yes`,
+		"unknown tag":     `{% include "other" %}`,
+		"unknown filter":  `{{ service.name | shout }}`,
+		"unexpected end":  `{% endif %}`,
+		"divided by zero": `{{ 10 | divided_by: 0 }}`,
+	}
+	for name, template := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := ol.RenderLiquid(template, nil)
+			// Assert
+			autopilot.Assert(t, err != nil, "expected an error")
+		})
+	}
+}
+
+func TestCustomActionsExternalActionPreview(t *testing.T) {
+	// Arrange
+	action := ol.CustomActionsExternalAction{
+		LiquidTemplate: `{"service": "{{ service.alias }}", "env": "{{ manualInputs.environment }}", "by": "{{ user.email }}"}`,
+	}
+	liquidContext := ol.LiquidContext{
+		Service:      &ol.Service{ServiceId: ol.ServiceId{Id: id1, Aliases: []string{"catalog_api"}}},
+		User:         &ol.User{UserId: ol.UserId{Email: "kyle@opslevel.com"}},
+		ManualInputs: ol.JSON{"environment": "staging"},
+	}
+	// Act
+	result, err := action.Preview(liquidContext)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `{"service": "catalog_api", "env": "staging", "by": "kyle@opslevel.com"}`, result)
+}
+
+func TestCustomEventCheckPreviewResultMessage(t *testing.T) {
+	// Arrange
+	check := ol.CustomEventCheckFragment{
+		ResultMessage: `{% if check.passed %}All good{% else %}{{ data.failures.size }} failures{% endif %}`,
+	}
+	liquidContext := ol.LiquidContext{
+		Extra: map[string]any{
+			"check": map[string]any{"passed": false},
+			"data":  map[string]any{"failures": []any{"a", "b"}},
+		},
+	}
+	// Act
+	result, err := check.PreviewResultMessage(liquidContext)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `2 failures`, result)
+}
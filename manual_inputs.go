@@ -0,0 +1,164 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManualInputType represents the kinds of manual inputs OpsLevel can render for a trigger definition.
+type ManualInputType string
+
+const (
+	ManualInputTypeTextInput ManualInputType = "text_input" // A single line of text.
+	ManualInputTypeTextArea  ManualInputType = "text_area"  // Multiple lines of text.
+	ManualInputTypeDropdown  ManualInputType = "dropdown"   // One value out of a fixed list of values.
+)
+
+// All ManualInputType as []string
+var AllManualInputType = []string{
+	string(ManualInputTypeTextInput),
+	string(ManualInputTypeTextArea),
+	string(ManualInputTypeDropdown),
+}
+
+// ManualInputsDefinition is the parsed form of CustomActionsTriggerDefinition.ManualInputsDefinition
+type ManualInputsDefinition struct {
+	Version int           `yaml:"version"`
+	Inputs  []ManualInput `yaml:"inputs"`
+}
+
+type ManualInput struct {
+	Identifier   string          `yaml:"identifier"`
+	DisplayName  string          `yaml:"displayName"`
+	Description  string          `yaml:"description,omitempty"`
+	Type         ManualInputType `yaml:"type"`
+	Required     bool            `yaml:"required,omitempty"`
+	DefaultValue *string         `yaml:"defaultValue,omitempty"`
+	MaxLength    int             `yaml:"maxLength,omitempty"`
+	Values       []string        `yaml:"values,omitempty"` // The options of a dropdown input
+}
+
+// ParseManualInputsDefinition parses the YAML and checks it only uses input types and options OpsLevel supports.
+// An empty definition is a trigger definition without manual inputs.
+func ParseManualInputsDefinition(data string) (*ManualInputsDefinition, error) {
+	if strings.TrimSpace(data) == "" {
+		return &ManualInputsDefinition{Version: 1}, nil
+	}
+	output := &ManualInputsDefinition{}
+	if err := yaml.Unmarshal([]byte(data), output); err != nil {
+		return nil, fmt.Errorf("unable to parse manual inputs definition: %w", err)
+	}
+	if err := output.validateDefinition(); err != nil {
+		return nil, fmt.Errorf("invalid manual inputs definition: %w", err)
+	}
+	return output, nil
+}
+
+func (definition *ManualInputsDefinition) validateDefinition() error {
+	var errs []error
+	if definition.Version != 1 {
+		errs = append(errs, fmt.Errorf("version must be 1. Given: '%d'", definition.Version))
+	}
+	seen := map[string]bool{}
+	for i, input := range definition.Inputs {
+		if input.Identifier == "" {
+			errs = append(errs, fmt.Errorf("input %d is missing an identifier", i))
+			continue
+		}
+		if seen[input.Identifier] {
+			errs = append(errs, fmt.Errorf("input '%s' is defined more than once", input.Identifier))
+		}
+		seen[input.Identifier] = true
+		errs = append(errs, input.validateDefinition())
+	}
+	return errors.Join(errs...)
+}
+
+func (input *ManualInput) validateDefinition() error {
+	var errs []error
+	if !slices.Contains(AllManualInputType, string(input.Type)) {
+		errs = append(errs, fmt.Errorf("input '%s' type must be one of %v. Given: '%s'", input.Identifier, AllManualInputType, input.Type))
+	}
+	if input.MaxLength < 0 {
+		errs = append(errs, fmt.Errorf("input '%s' maxLength must not be negative", input.Identifier))
+	}
+	switch {
+	case input.Type == ManualInputTypeDropdown && len(input.Values) == 0:
+		errs = append(errs, fmt.Errorf("input '%s' of type '%s' must define values", input.Identifier, input.Type))
+	case input.Type != ManualInputTypeDropdown && len(input.Values) > 0:
+		errs = append(errs, fmt.Errorf("input '%s' of type '%s' cannot define values", input.Identifier, input.Type))
+	}
+	if input.DefaultValue != nil {
+		if err := input.validateValue(*input.DefaultValue); err != nil {
+			errs = append(errs, fmt.Errorf("defaultValue: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (input *ManualInput) validateValue(value any) error {
+	text, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("manual input '%s' must be a string. Given: '%v'", input.Identifier, value)
+	}
+	if input.MaxLength > 0 && utf8.RuneCountInString(text) > input.MaxLength {
+		return fmt.Errorf("manual input '%s' must be at most %d characters", input.Identifier, input.MaxLength)
+	}
+	if input.Type == ManualInputTypeDropdown && !slices.Contains(input.Values, text) {
+		return fmt.Errorf("manual input '%s' must be one of %v. Given: '%s'", input.Identifier, input.Values, text)
+	}
+	return nil
+}
+
+// Get Given an 'identifier' returns the matching 'ManualInput'
+func (definition *ManualInputsDefinition) Get(identifier string) *ManualInput {
+	for i, input := range definition.Inputs {
+		if input.Identifier == identifier {
+			return &definition.Inputs[i]
+		}
+	}
+	return nil
+}
+
+// WithDefaults returns a copy of 'inputs' with the default value set for every missing input that has one
+func (definition *ManualInputsDefinition) WithDefaults(inputs JSON) JSON {
+	output := JSON{}
+	maps.Copy(output, inputs)
+	for _, input := range definition.Inputs {
+		if _, ok := output[input.Identifier]; !ok && input.DefaultValue != nil {
+			output[input.Identifier] = *input.DefaultValue
+		}
+	}
+	return output
+}
+
+// Validate checks that 'inputs' only contains defined inputs, that every required input
+// is present and that each value matches the type and options of its input
+func (definition *ManualInputsDefinition) Validate(inputs JSON) error {
+	var errs []error
+	keys := make([]string, 0, len(inputs))
+	for key := range inputs {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		input := definition.Get(key)
+		if input == nil {
+			errs = append(errs, fmt.Errorf("manual input '%s' is not defined", key))
+			continue
+		}
+		errs = append(errs, input.validateValue(inputs[key]))
+	}
+	for _, input := range definition.Inputs {
+		if _, ok := inputs[input.Identifier]; input.Required && !ok {
+			errs = append(errs, fmt.Errorf("manual input '%s' is required", input.Identifier))
+		}
+	}
+	return errors.Join(errs...)
+}
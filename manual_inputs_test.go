@@ -0,0 +1,140 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+const manualInputsDefinition = `---
+version: 1
+inputs:
+  - identifier: reason
+    displayName: Reason
+    description: Why the rollback is needed
+    type: text_input
+    required: true
+    maxLength: 20
+  - identifier: priority
+    displayName: Priority
+    type: dropdown
+    required: true
+    defaultValue: P3
+    values:
+      - P1
+      - P2
+      - P3
+  - identifier: notes
+    displayName: Notes
+    type: text_area
+`
+
+func TestParseManualInputsDefinition(t *testing.T) {
+	// Act
+	result, err := ol.ParseManualInputsDefinition(manualInputsDefinition)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, result.Version)
+	autopilot.Equals(t, 3, len(result.Inputs))
+	autopilot.Equals(t, "Why the rollback is needed", result.Get("reason").Description)
+	autopilot.Equals(t, ol.ManualInputTypeTextInput, result.Get("reason").Type)
+	autopilot.Equals(t, 20, result.Get("reason").MaxLength)
+	autopilot.Equals(t, "P3", *result.Get("priority").DefaultValue)
+	autopilot.Equals(t, []string{"P1", "P2", "P3"}, result.Get("priority").Values)
+	autopilot.Equals(t, false, result.Get("notes").Required)
+	autopilot.Assert(t, result.Get("missing") == nil, "expected no input for an unknown identifier")
+}
+
+func TestParseManualInputsDefinitionEmpty(t *testing.T) {
+	for _, data := range []string{"", "  \n\t"} {
+		// Act
+		result, err := ol.ParseManualInputsDefinition(data)
+		// Assert
+		autopilot.Ok(t, err)
+		autopilot.Equals(t, 0, len(result.Inputs))
+		autopilot.Ok(t, result.Validate(nil))
+	}
+}
+
+func TestParseManualInputsDefinitionInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		definition string
+		expected   string
+	}{
+		"malformed yaml": {
+			definition: "inputs: [",
+			expected:   "unable to parse manual inputs definition: yaml: line 1: did not find expected node content",
+		},
+		"unsupported version": {
+			definition: "version: 2\ninputs: []\n",
+			expected:   "invalid manual inputs definition: version must be 1. Given: '2'",
+		},
+		"unsupported type": {
+			definition: "version: 1\ninputs:\n  - identifier: notify\n    type: boolean\n",
+			expected:   "invalid manual inputs definition: input 'notify' type must be one of [text_input text_area dropdown]. Given: 'boolean'",
+		},
+		"dropdown without values": {
+			definition: "version: 1\ninputs:\n  - identifier: priority\n    type: dropdown\n",
+			expected:   "invalid manual inputs definition: input 'priority' of type 'dropdown' must define values",
+		},
+		"duplicate identifier": {
+			definition: "version: 1\ninputs:\n  - identifier: reason\n    type: text_input\n  - identifier: reason\n    type: text_area\n",
+			expected:   "invalid manual inputs definition: input 'reason' is defined more than once",
+		},
+		"invalid default": {
+			definition: "version: 1\ninputs:\n  - identifier: priority\n    type: dropdown\n    defaultValue: P4\n    values: [P1]\n",
+			expected:   "invalid manual inputs definition: defaultValue: manual input 'priority' must be one of [P1]. Given: 'P4'",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := ol.ParseManualInputsDefinition(tc.definition)
+			// Assert
+			autopilot.Equals(t, tc.expected, err.Error())
+		})
+	}
+}
+
+func TestManualInputsDefinitionValidate(t *testing.T) {
+	definition, err := ol.ParseManualInputsDefinition(manualInputsDefinition)
+	autopilot.Ok(t, err)
+	testCases := map[string]struct {
+		inputs   ol.JSON
+		expected string
+	}{
+		"valid":            {inputs: ol.JSON{"reason": "bad deploy", "priority": "P1", "notes": "see incident"}},
+		"optional omitted": {inputs: ol.JSON{"reason": "bad deploy", "priority": "P2"}},
+		"missing required": {inputs: ol.JSON{"priority": "P1"}, expected: "manual input 'reason' is required"},
+		"unknown input":    {inputs: ol.JSON{"reason": "bad deploy", "priority": "P1", "ticket": "INC-1"}, expected: "manual input 'ticket' is not defined"},
+		"too long":         {inputs: ol.JSON{"reason": "the deploy broke every single endpoint", "priority": "P1"}, expected: "manual input 'reason' must be at most 20 characters"},
+		"not an option":    {inputs: ol.JSON{"reason": "bad deploy", "priority": "P0"}, expected: "manual input 'priority' must be one of [P1 P2 P3]. Given: 'P0'"},
+		"not a string":     {inputs: ol.JSON{"reason": 42, "priority": "P1"}, expected: "manual input 'reason' must be a string. Given: '42'"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := definition.Validate(tc.inputs)
+			// Assert
+			if tc.expected == "" {
+				autopilot.Ok(t, err)
+			} else {
+				autopilot.Equals(t, tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestManualInputsDefinitionWithDefaults(t *testing.T) {
+	// Arrange
+	definition, err := ol.ParseManualInputsDefinition(manualInputsDefinition)
+	autopilot.Ok(t, err)
+	inputs := ol.JSON{"reason": "bad deploy"}
+	// Act
+	result := definition.WithDefaults(inputs)
+	// Assert
+	autopilot.Equals(t, ol.JSON{"reason": "bad deploy", "priority": "P3"}, result)
+	autopilot.Equals(t, ol.JSON{"reason": "bad deploy"}, inputs)
+	autopilot.Ok(t, definition.Validate(result))
+}
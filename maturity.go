@@ -1,5 +1,11 @@
 package opslevel
 
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
 type CategoryBreakdown struct {
 	Category Category
 	Level    Level
@@ -11,6 +17,7 @@ type MaturityReport struct {
 }
 
 type ServiceMaturity struct {
+	Id             ID
 	Name           string
 	MaturityReport MaturityReport
 }
@@ -70,3 +77,104 @@ func (client *Client) ListServicesMaturity(variables *PayloadVariables) (*Servic
 	q.Account.Services.TotalCount = len(q.Account.Services.Nodes)
 	return &q.Account.Services, nil
 }
+
+// LevelPath lists the failing checks that must pass to move from Current to Next.
+// Category is nil when the path is for the overall level.
+type LevelPath struct {
+	Category *Category
+	Current  Level
+	Next     *Level
+	Checks   []CheckResult
+}
+
+type PathToNextLevel struct {
+	Overall    LevelPath
+	Categories []LevelPath
+}
+
+// NextLevel Given the rubric 'levels' returns the 'Level' directly above 'current' or nil if there is none
+func NextLevel(levels []Level, current Level) *Level {
+	var output *Level
+	for _, level := range levels {
+		if level.Index <= current.Index {
+			continue
+		}
+		if output == nil || level.Index < output.Index {
+			output = &level
+		}
+	}
+	return output
+}
+
+// NewPathToNextLevel combines a maturity report with the service's check results to list
+// the failing checks blocking the next level overall and in each category, ordered by level index
+func NewPathToNextLevel(report MaturityReport, levels []Level, results []CheckResult) *PathToNextLevel {
+	output := &PathToNextLevel{
+		Overall: newLevelPath(nil, report.OverallLevel, levels, results),
+	}
+	for _, breakdown := range report.CategoryBreakdown {
+		// Categories without a level have no checks that apply to the service
+		if breakdown.Level.Id == "" {
+			continue
+		}
+		category := breakdown.Category
+		output.Categories = append(output.Categories, newLevelPath(&category, breakdown.Level, levels, results))
+	}
+	return output
+}
+
+func newLevelPath(category *Category, current Level, levels []Level, results []CheckResult) LevelPath {
+	output := LevelPath{
+		Category: category,
+		Current:  current,
+		Next:     NextLevel(levels, current),
+	}
+	if output.Next == nil {
+		return output
+	}
+	for _, result := range results {
+		if result.Passed() || result.Check.Level.Index > output.Next.Index {
+			continue
+		}
+		if category != nil && result.Check.Category.Id != category.Id {
+			continue
+		}
+		output.Checks = append(output.Checks, result)
+	}
+	slices.SortStableFunc(output.Checks, func(a, b CheckResult) int {
+		return cmp.Or(
+			cmp.Compare(a.Check.Level.Index, b.Check.Level.Index),
+			cmp.Compare(a.Check.Category.Name, b.Check.Category.Name),
+			cmp.Compare(a.Check.Name, b.Check.Name),
+		)
+	})
+	return output
+}
+
+func (service *Service) GetPathToNextLevel(client *Client) (*PathToNextLevel, error) {
+	var q struct {
+		Account struct {
+			Service struct {
+				MaturityReport MaturityReport
+			} `graphql:"service(id: $service)"`
+		}
+	}
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to get PathToNextLevel, invalid service id: '%s'", service.Id)
+	}
+	v := PayloadVariables{
+		"service": service.Id,
+	}
+	if err := client.Query(&q, v, WithName("ServiceMaturityReportGet")); err != nil {
+		return nil, err
+	}
+	levels, err := client.ListLevels()
+	if err != nil {
+		return nil, err
+	}
+	results, err := service.GetCheckResults(client, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewPathToNextLevel(q.Account.Service.MaturityReport, levels, results.Nodes), nil
+}
@@ -0,0 +1,238 @@
+package opslevel
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+type MaturityLevelSnapshot struct {
+	Id    ID     `json:"id"`
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+}
+
+// MaturityCategorySnapshot is the level of a service in one category, Name is only kept for display
+type MaturityCategorySnapshot struct {
+	Name  string                `json:"name"`
+	Level MaturityLevelSnapshot `json:"level"`
+}
+
+// MaturitySnapshot records the overall and per category levels of a service at a point in time
+type MaturitySnapshot struct {
+	ServiceId   ID                              `json:"serviceId"`
+	ServiceName string                          `json:"serviceName"`
+	TakenAt     time.Time                       `json:"takenAt"`
+	Overall     MaturityLevelSnapshot           `json:"overall"`
+	Categories  map[ID]MaturityCategorySnapshot `json:"categories,omitempty"` // Keyed by category id so renames keep their history
+}
+
+// MaturitySnapshotStore persists maturity snapshots between runs.
+// The interface is kept small so it can be backed by a file, SQLite or any other database.
+type MaturitySnapshotStore interface {
+	Append(snapshots ...MaturitySnapshot) error
+	Load() ([]MaturitySnapshot, error)
+}
+
+// JSONLinesMaturityStore stores one snapshot per line in a local file
+type JSONLinesMaturityStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// MaturityChange is a level change of a service overall or, when CategoryId is set, in one category
+type MaturityChange struct {
+	ServiceId   ID
+	ServiceName string
+	CategoryId  ID
+	Category    string // The category name in the latest snapshot
+	From        MaturityLevelSnapshot
+	To          MaturityLevelSnapshot
+}
+
+type MaturityDelta struct {
+	Improved    []MaturityChange
+	Regressed   []MaturityChange
+	TimeInLevel map[ID]time.Duration // How long each service has been at its current overall level
+}
+
+func NewJSONLinesMaturityStore(path string) *JSONLinesMaturityStore {
+	return &JSONLinesMaturityStore{Path: path}
+}
+
+func (store *JSONLinesMaturityStore) Append(snapshots ...MaturitySnapshot) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	file, err := os.OpenFile(store.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	for _, snapshot := range snapshots {
+		if err := encoder.Encode(snapshot); err != nil {
+			return errors.Join(err, file.Close())
+		}
+	}
+	return file.Close()
+}
+
+func (store *JSONLinesMaturityStore) Load() ([]MaturitySnapshot, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	file, err := os.Open(store.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var output []MaturitySnapshot
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var snapshot MaturitySnapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, err
+		}
+		output = append(output, snapshot)
+	}
+	return output, scanner.Err()
+}
+
+func newMaturityLevelSnapshot(level Level) MaturityLevelSnapshot {
+	return MaturityLevelSnapshot{
+		Id:    level.Id,
+		Index: level.Index,
+		Name:  level.Name,
+	}
+}
+
+func NewMaturitySnapshot(service ServiceMaturity, takenAt time.Time) MaturitySnapshot {
+	output := MaturitySnapshot{
+		ServiceId:   service.Id,
+		ServiceName: service.Name,
+		TakenAt:     takenAt.UTC(),
+		Overall:     newMaturityLevelSnapshot(service.MaturityReport.OverallLevel),
+		Categories:  map[ID]MaturityCategorySnapshot{},
+	}
+	for _, breakdown := range service.MaturityReport.CategoryBreakdown {
+		if breakdown.Level.Id == "" {
+			continue
+		}
+		output.Categories[breakdown.Category.Id] = MaturityCategorySnapshot{
+			Name:  breakdown.Category.Name,
+			Level: newMaturityLevelSnapshot(breakdown.Level),
+		}
+	}
+	return output
+}
+
+// RecordMaturitySnapshots takes a snapshot of every service's maturity and appends it to the store
+func (client *Client) RecordMaturitySnapshots(store MaturitySnapshotStore) ([]MaturitySnapshot, error) {
+	services, err := client.ListServicesMaturity(nil)
+	if err != nil {
+		return nil, err
+	}
+	takenAt := time.Now()
+	output := make([]MaturitySnapshot, 0, len(services.Nodes))
+	for _, service := range services.Nodes {
+		output = append(output, NewMaturitySnapshot(service, takenAt))
+	}
+	return output, store.Append(output...)
+}
+
+// CompareMaturity compares each service's latest snapshot in 'history' against its
+// latest snapshot taken at or before 'since' and reports level changes and time in level
+func CompareMaturity(history []MaturitySnapshot, since time.Time) *MaturityDelta {
+	byService := map[ID][]MaturitySnapshot{}
+	for _, snapshot := range history {
+		byService[snapshot.ServiceId] = append(byService[snapshot.ServiceId], snapshot)
+	}
+
+	output := &MaturityDelta{TimeInLevel: map[ID]time.Duration{}}
+	for serviceId, snapshots := range byService {
+		slices.SortStableFunc(snapshots, func(a, b MaturitySnapshot) int {
+			return a.TakenAt.Compare(b.TakenAt)
+		})
+		current := snapshots[len(snapshots)-1]
+		output.TimeInLevel[serviceId] = timeInLevel(snapshots)
+
+		baselineIndex := -1
+		for i, snapshot := range snapshots {
+			if snapshot.TakenAt.After(since) {
+				break
+			}
+			baselineIndex = i
+		}
+		if baselineIndex < 0 {
+			continue
+		}
+		output.addChanges(snapshots[baselineIndex], current)
+	}
+
+	sortChanges := func(a, b MaturityChange) int {
+		return cmp.Or(
+			cmp.Compare(a.ServiceName, b.ServiceName),
+			cmp.Compare(a.Category, b.Category),
+			cmp.Compare(a.CategoryId, b.CategoryId),
+		)
+	}
+	slices.SortFunc(output.Improved, sortChanges)
+	slices.SortFunc(output.Regressed, sortChanges)
+	return output
+}
+
+func (delta *MaturityDelta) addChanges(baseline, current MaturitySnapshot) {
+	delta.add(MaturityChange{
+		ServiceId:   current.ServiceId,
+		ServiceName: current.ServiceName,
+		From:        baseline.Overall,
+		To:          current.Overall,
+	})
+	for categoryId, category := range current.Categories {
+		previous, ok := baseline.Categories[categoryId]
+		if !ok {
+			continue
+		}
+		delta.add(MaturityChange{
+			ServiceId:   current.ServiceId,
+			ServiceName: current.ServiceName,
+			CategoryId:  categoryId,
+			Category:    category.Name,
+			From:        previous.Level,
+			To:          category.Level,
+		})
+	}
+}
+
+func (delta *MaturityDelta) add(change MaturityChange) {
+	switch {
+	case change.To.Index > change.From.Index:
+		delta.Improved = append(delta.Improved, change)
+	case change.To.Index < change.From.Index:
+		delta.Regressed = append(delta.Regressed, change)
+	}
+}
+
+// timeInLevel expects 'snapshots' to be sorted oldest first
+func timeInLevel(snapshots []MaturitySnapshot) time.Duration {
+	current := snapshots[len(snapshots)-1]
+	start := current.TakenAt
+	for i := len(snapshots) - 2; i >= 0; i-- {
+		if snapshots[i].Overall.Index != current.Overall.Index {
+			break
+		}
+		start = snapshots[i].TakenAt
+	}
+	return current.TakenAt.Sub(start)
+}
@@ -0,0 +1,95 @@
+package opslevel_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+var (
+	historyBronze = ol.MaturityLevelSnapshot{Id: "bronze", Index: 1, Name: "Bronze"}
+	historySilver = ol.MaturityLevelSnapshot{Id: "silver", Index: 2, Name: "Silver"}
+	historyGold   = ol.MaturityLevelSnapshot{Id: "gold", Index: 3, Name: "Gold"}
+	historyStart  = time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func historySnapshot(service string, day int, overall ol.MaturityLevelSnapshot, categories map[ol.ID]ol.MaturityCategorySnapshot) ol.MaturitySnapshot {
+	return ol.MaturitySnapshot{
+		ServiceId:   ol.ID(service),
+		ServiceName: service,
+		TakenAt:     historyStart.AddDate(0, 0, day),
+		Overall:     overall,
+		Categories:  categories,
+	}
+}
+
+func TestJSONLinesMaturityStore(t *testing.T) {
+	// Arrange
+	store := ol.NewJSONLinesMaturityStore(filepath.Join(t.TempDir(), "maturity.jsonl"))
+	first := historySnapshot("api", 0, historyBronze, map[ol.ID]ol.MaturityCategorySnapshot{"security": {Name: "Security", Level: historySilver}})
+	second := historySnapshot("api", 7, historySilver, nil)
+	// Act
+	empty, emptyErr := store.Load()
+	firstErr := store.Append(first)
+	secondErr := store.Append(second)
+	result, err := store.Load()
+	// Assert
+	autopilot.Ok(t, emptyErr)
+	autopilot.Equals(t, 0, len(empty))
+	autopilot.Ok(t, firstErr)
+	autopilot.Ok(t, secondErr)
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.MaturitySnapshot{first, second}, result)
+}
+
+func TestCompareMaturity(t *testing.T) {
+	// Arrange
+	history := []ol.MaturitySnapshot{
+		historySnapshot("api", 0, historyBronze, map[ol.ID]ol.MaturityCategorySnapshot{"security": {Name: "Security", Level: historyBronze}}),
+		historySnapshot("api", 7, historySilver, map[ol.ID]ol.MaturityCategorySnapshot{"security": {Name: "Security", Level: historyGold}}),
+		historySnapshot("api", 14, historySilver, map[ol.ID]ol.MaturityCategorySnapshot{"security": {Name: "Secure Development", Level: historyGold}}),
+		historySnapshot("web", 14, historySilver, nil),
+		historySnapshot("web", 0, historyGold, nil),
+		historySnapshot("worker", 7, historyBronze, nil),
+	}
+	// Act
+	delta := ol.CompareMaturity(history, historyStart.AddDate(0, 0, 1))
+	// Assert
+	autopilot.Equals(t, 2, len(delta.Improved))
+	autopilot.Equals(t, "", delta.Improved[0].Category)
+	autopilot.Equals(t, historySilver, delta.Improved[0].To)
+	autopilot.Equals(t, ol.ID("security"), delta.Improved[1].CategoryId)
+	autopilot.Equals(t, "Secure Development", delta.Improved[1].Category)
+	autopilot.Equals(t, 1, len(delta.Regressed))
+	autopilot.Equals(t, ol.ID("web"), delta.Regressed[0].ServiceId)
+	autopilot.Equals(t, historyGold, delta.Regressed[0].From)
+	autopilot.Equals(t, 7*24*time.Hour, delta.TimeInLevel["api"])
+	autopilot.Equals(t, time.Duration(0), delta.TimeInLevel["web"])
+	autopilot.Equals(t, time.Duration(0), delta.TimeInLevel["worker"])
+}
+
+func TestRecordMaturitySnapshots(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query ServiceMaturityList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{id,name,maturityReport{categoryBreakdown{category{id,name},level{alias,description,id,index,name}},overallLevel{alias,description,id,index,name}}},{{ template "pagination_request" }}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{"data": {"account": {"services": {"nodes": [ { {{ template "id1" }}, "name": "api", "maturityReport": {"categoryBreakdown": [ {"category": {{ template "category_1" }}, "level": {{ template "level_2" }} }, {"category": {{ template "category_2" }}, "level": null } ], "overallLevel": {{ template "level_1" }} }} ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	client := BestTestClient(t, "maturity/record_snapshots", testRequest)
+	store := ol.NewJSONLinesMaturityStore(filepath.Join(t.TempDir(), "maturity.jsonl"))
+	// Act
+	result, err := client.RecordMaturitySnapshots(store)
+	stored, loadErr := store.Load()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Ok(t, loadErr)
+	autopilot.Equals(t, 1, len(result))
+	autopilot.Equals(t, id1, result[0].ServiceId)
+	autopilot.Equals(t, 1, result[0].Overall.Index)
+	autopilot.Equals(t, 1, len(result[0].Categories))
+	autopilot.Equals(t, 2, result[0].Categories[id1].Level.Index)
+	autopilot.Equals(t, result, stored)
+}
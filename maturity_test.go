@@ -3,13 +3,14 @@ package opslevel_test
 import (
 	"testing"
 
+	ol "github.com/opslevel/opslevel-go/v2024"
 	"github.com/rocktavious/autopilot/v2023"
 )
 
 func TestGetServiceMaturityWithAlias(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`query ($service:String!){account{service(alias:$service){name,maturityReport{categoryBreakdown{category{id,name},level{alias,description,id,index,name}},overallLevel{alias,description,id,index,name}}}}}`,
+		`query ($service:String!){account{service(alias:$service){id,name,maturityReport{categoryBreakdown{category{id,name},level{alias,description,id,index,name}},overallLevel{alias,description,id,index,name}}}}}`,
 		`{"service": "cert-manager"}`,
 		`{
   "data": {
@@ -133,7 +134,7 @@ func TestGetServiceMaturityWithAlias(t *testing.T) {
 func TestListServicesMaturity(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`query ServiceMaturityList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{name,maturityReport{categoryBreakdown{category{id,name},level{alias,description,id,index,name}},overallLevel{alias,description,id,index,name}}},{{ template "pagination_request" }}}}}`,
+		`query ServiceMaturityList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{id,name,maturityReport{categoryBreakdown{category{id,name},level{alias,description,id,index,name}},overallLevel{alias,description,id,index,name}}},{{ template "pagination_request" }}}}}`,
 		`{"after":"", "first":100}`,
 		`{
   "data": {
@@ -237,3 +238,92 @@ func TestListServicesMaturity(t *testing.T) {
 	autopilot.Equals(t, "Example", result[0].Name)
 	autopilot.Equals(t, "Gold", result[0].MaturityReport.Get("Quality").Name)
 }
+
+func TestNewPathToNextLevel(t *testing.T) {
+	// Arrange
+	bronze := ol.Level{Id: "bronze", Index: 1, Name: "Bronze"}
+	silver := ol.Level{Id: "silver", Index: 2, Name: "Silver"}
+	gold := ol.Level{Id: "gold", Index: 3, Name: "Gold"}
+	security := ol.Category{Id: "security", Name: "Security"}
+	reliability := ol.Category{Id: "reliability", Name: "Reliability"}
+	result := func(name string, category ol.Category, level ol.Level, status ol.CheckResultStatusEnum) ol.CheckResult {
+		return ol.CheckResult{
+			Check:  ol.CheckSummary{Category: category, Level: level, Name: name},
+			Status: status,
+		}
+	}
+	report := ol.MaturityReport{
+		CategoryBreakdown: []ol.CategoryBreakdown{
+			{Category: security, Level: silver},
+			{Category: reliability, Level: bronze},
+			{Category: ol.Category{Id: "performance", Name: "Performance"}},
+		},
+		OverallLevel: bronze,
+	}
+	results := []ol.CheckResult{
+		result("Has Runbook", reliability, gold, ol.CheckResultStatusEnumFailed),
+		result("Has Alerts", reliability, silver, ol.CheckResultStatusEnumFailed),
+		result("No Secrets", security, gold, ol.CheckResultStatusEnumFailed),
+		result("Has SAST", security, silver, ol.CheckResultStatusEnumPassed),
+		result("Has Owner", security, bronze, ol.CheckResultStatusEnumPassed),
+	}
+	// Act
+	path := ol.NewPathToNextLevel(report, []ol.Level{gold, bronze, silver}, results)
+	// Assert
+	autopilot.Equals(t, silver, *path.Overall.Next)
+	autopilot.Equals(t, 1, len(path.Overall.Checks))
+	autopilot.Equals(t, "Has Alerts", path.Overall.Checks[0].Check.Name)
+	autopilot.Equals(t, 2, len(path.Categories))
+	autopilot.Equals(t, security, *path.Categories[0].Category)
+	autopilot.Equals(t, gold, *path.Categories[0].Next)
+	autopilot.Equals(t, "No Secrets", path.Categories[0].Checks[0].Check.Name)
+	autopilot.Equals(t, reliability, *path.Categories[1].Category)
+	autopilot.Equals(t, 1, len(path.Categories[1].Checks))
+}
+
+func TestNextLevel(t *testing.T) {
+	// Arrange
+	levels := []ol.Level{{Id: "gold", Index: 3}, {Id: "bronze", Index: 1}, {Id: "silver", Index: 2}}
+	// Act
+	next := ol.NextLevel(levels, levels[1])
+	last := ol.NextLevel(levels, levels[0])
+	// Assert
+	autopilot.Equals(t, ol.ID("silver"), next.Id)
+	autopilot.Equals(t, true, last == nil)
+}
+
+func TestServiceGetPathToNextLevel(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceMaturityReportGet($service:ID!){account{service(id: $service){maturityReport{categoryBreakdown{category{id,name},level{alias,description,id,index,name}},overallLevel{alias,description,id,index,name}}}}}`,
+		`{"service": "{{ template "id1_string" }}"}`,
+		`{"data": {"account": {"service": {"maturityReport": {"categoryBreakdown": [ {"category": {{ template "category_1" }}, "level": {{ template "level_1" }} }, {"category": {{ template "category_2" }}, "level": {{ template "level_1" }} } ], "overallLevel": {{ template "level_1" }} }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`{account{rubric{levels{nodes{alias,description,id,index,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{}`,
+		`{"data": {"account": {"rubric": {"levels": {"nodes": [ {{ template "level_1" }}, {{ template "level_2" }}, {{ template "level_3" }} ], {{ template "no_pagination_response" }}, "totalCount": 3 }}}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`query ServiceCheckResultsList($after:String!$first:Int!$service:ID!){account{service(id: $service){checkResults(after: $after, first: $first){nodes{ {{- template "check_result_request" -}} },{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{ "data": { "account": { "service": { "checkResults": { "nodes": [ {{ template "check_result_1" }}, {{ template "check_result_2" }}, {{ template "check_result_3" }} ], {{ template "no_pagination_response" }}, "totalCount": 3 }}}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "maturity/path_to_next_level", requests...)
+	service := ol.Service{
+		ServiceId: ol.ServiceId{
+			Id: id1,
+		},
+	}
+	// Act
+	path, err := service.GetPathToNextLevel(client)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, path.Overall.Next.Index)
+	autopilot.Equals(t, 1, len(path.Overall.Checks))
+	autopilot.Equals(t, "Has Owner", path.Overall.Checks[0].Check.Name)
+	autopilot.Equals(t, 1, len(path.Categories[0].Checks))
+	autopilot.Equals(t, 0, len(path.Categories[1].Checks))
+}
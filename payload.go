@@ -0,0 +1,76 @@
+package opslevel
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/relvacode/iso8601"
+)
+
+// Payload is a request an integration received, ex: a check result sent to a payload check
+type Payload struct {
+	Id          ID            `graphql:"id"`
+	CreatedAt   iso8601.Time  `graphql:"createdAt"`
+	ProcessedAt *iso8601.Time `graphql:"processedAt"`
+	Integration IntegrationId `graphql:"integration"`
+}
+
+type PayloadConnection struct {
+	Nodes      []Payload `graphql:"nodes"`
+	PageInfo   PageInfo  `graphql:"pageInfo"`
+	TotalCount int       `graphql:"totalCount"`
+}
+
+func (client *Client) ListPayloads(variables *PayloadVariables) (*PayloadConnection, error) {
+	var q struct {
+		Account struct {
+			Payloads PayloadConnection `graphql:"payloads(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("PayloadList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Payloads.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Payloads.PageInfo.End
+		resp, err := client.ListPayloads(variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Payloads.Nodes = append(q.Account.Payloads.Nodes, resp.Nodes...)
+		q.Account.Payloads.PageInfo = resp.PageInfo
+	}
+	q.Account.Payloads.TotalCount = len(q.Account.Payloads.Nodes)
+	return &q.Account.Payloads, nil
+}
+
+func (client *Client) ListPayloadsWithSort(sortBy PayloadSortEnum, variables *PayloadVariables) (*PayloadConnection, error) {
+	var q struct {
+		Account struct {
+			Payloads PayloadConnection `graphql:"payloads(sortBy: $sortBy, after: $after, first: $first)"`
+		}
+	}
+	if !slices.Contains(AllPayloadSortEnum, string(sortBy)) {
+		return nil, fmt.Errorf("sortBy must be one of %v. Given: '%s'", AllPayloadSortEnum, sortBy)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["sortBy"] = sortBy
+	if err := client.Query(&q, *variables, WithName("PayloadListWithSort")); err != nil {
+		return nil, err
+	}
+	for q.Account.Payloads.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Payloads.PageInfo.End
+		resp, err := client.ListPayloadsWithSort(sortBy, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Payloads.Nodes = append(q.Account.Payloads.Nodes, resp.Nodes...)
+		q.Account.Payloads.PageInfo = resp.PageInfo
+	}
+	q.Account.Payloads.TotalCount = len(q.Account.Payloads.Nodes)
+	return &q.Account.Payloads, nil
+}
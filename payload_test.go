@@ -0,0 +1,61 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestListPayloads(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query PayloadList($after:String!$first:Int!){account{payloads(after: $after, first: $first){nodes{id,createdAt,processedAt,integration{id,name,type}},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "payloads": { "nodes": [ { {{ template "id1" }}, "createdAt": "2026-10-01T10:00:00Z", "processedAt": "2026-10-01T10:00:05Z", "integration": { {{ template "id3" }}, "name": "Deploys", "type": "generic" } } ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query PayloadList($after:String!$first:Int!){account{payloads(after: $after, first: $first){nodes{id,createdAt,processedAt,integration{id,name,type}},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_second_query_variables" }}`,
+		`{ "data": { "account": { "payloads": { "nodes": [ { {{ template "id2" }}, "createdAt": "2026-10-02T10:00:00Z", "processedAt": null, "integration": { {{ template "id3" }}, "name": "Deploys", "type": "generic" } } ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "payloads/list", requests...)
+	// Act
+	response, err := client.ListPayloads(nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, "Deploys", response.Nodes[0].Integration.Name)
+	autopilot.Assert(t, response.Nodes[1].ProcessedAt == nil, "expected the second payload to be unprocessed")
+}
+
+func TestListPayloadsWithSort(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query PayloadListWithSort($after:String!$first:Int!$sortBy:PayloadSortEnum!){account{payloads(sortBy: $sortBy, after: $after, first: $first){nodes{id,createdAt,processedAt,integration{id,name,type}},{{ template "pagination_request" }},totalCount}}}`,
+		`{ {{ template "first_page_variables" }}, "sortBy": "created_at_ASC" }`,
+		`{ "data": { "account": { "payloads": { "nodes": [
+			{ {{ template "id1" }}, "createdAt": "2026-10-01T10:00:00Z", "processedAt": "2026-10-01T10:00:05Z", "integration": { {{ template "id3" }}, "name": "Deploys", "type": "generic" } },
+			{ {{ template "id2" }}, "createdAt": "2026-10-02T10:00:00Z", "processedAt": null, "integration": { {{ template "id3" }}, "name": "Deploys", "type": "generic" } }
+		], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+
+	client := BestTestClient(t, "payloads/list_with_sort", testRequest)
+	// Act
+	response, err := client.ListPayloadsWithSort(ol.PayloadSortEnumCreatedAtAsc, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, id1, response.Nodes[0].Id)
+}
+
+func TestListPayloadsWithSortInvalid(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "payloads/list_with_sort_invalid")
+	// Act
+	_, err := client.ListPayloadsWithSort(ol.PayloadSortEnum("updated_at_ASC"), nil)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown sort option")
+}
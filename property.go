@@ -1,6 +1,12 @@
 package opslevel
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
 
 // PropertyDefinition represents the definition of a property.
 type PropertyDefinition struct {
@@ -200,3 +206,101 @@ func (service *Service) GetProperties(client *Client, variables *PayloadVariable
 	}
 	return service.Properties, nil
 }
+
+// PropertyReconcileResult reports what ReconcileProperties changed and what it could not change
+type PropertyReconcileResult struct {
+	Assigned   []Property             // Properties whose value was set
+	Unassigned []PropertyDefinitionId // Definitions whose value was removed
+	Locked     []Property             // Locked properties that differ from the desired value, these are left unchanged
+	Invalid    []Property             // Assigned properties whose value fails validation against the definition's schema
+}
+
+// ReconcileProperties manages the custom property values of a service, the only resource custom properties can be assigned to
+//
+// 'desired' maps a property definition alias or id to its value. Values are compared to the current
+// values semantically as JSON, ex: `{"a":1,"b":2}` and `{"b": 2, "a": 1}` are equal. Changed values
+// are assigned and values of definitions not in 'desired' are unassigned, locked properties are skipped.
+func (client *Client) ReconcileProperties(service *Service, desired map[string]any) (*PropertyReconcileResult, error) {
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to reconcile properties, invalid service id: '%s'", service.Id)
+	}
+	// Values may have changed since the properties were loaded, so they are always fetched again
+	service.Properties = nil
+	connection, err := service.GetProperties(client, nil)
+	if err != nil {
+		return nil, err
+	}
+	current := connection.Nodes
+
+	result := &PropertyReconcileResult{}
+	var allErrors error
+	for _, property := range current {
+		if property.Value == nil || slices.ContainsFunc(mapKeys(desired), property.Definition.matches) {
+			continue
+		}
+		if property.Locked {
+			result.Locked = append(result.Locked, property)
+			continue
+		}
+		if err := client.PropertyUnassign(string(service.Id), string(property.Definition.Id)); err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		result.Unassigned = append(result.Unassigned, property.Definition)
+	}
+	for _, definition := range mapKeys(desired) {
+		wanted, err := NewJSONInput(desired[definition])
+		if err != nil {
+			allErrors = errors.Join(allErrors, fmt.Errorf("property '%s': %w", definition, err))
+			continue
+		}
+		index := slices.IndexFunc(current, func(property Property) bool { return property.Definition.matches(definition) })
+		if index >= 0 && current[index].Value != nil && jsonEqual(*current[index].Value, *wanted) {
+			continue
+		}
+		if index >= 0 && current[index].Locked {
+			result.Locked = append(result.Locked, current[index])
+			continue
+		}
+		property, err := client.PropertyAssign(PropertyInput{
+			Owner:      *NewIdentifier(string(service.Id)),
+			Definition: *NewIdentifier(definition),
+			Value:      *wanted,
+		})
+		if err != nil {
+			allErrors = errors.Join(allErrors, fmt.Errorf("property '%s': %w", definition, err))
+			continue
+		}
+		result.Assigned = append(result.Assigned, *property)
+		if len(property.ValidationErrors) > 0 {
+			result.Invalid = append(result.Invalid, *property)
+		}
+	}
+	return result, allErrors
+}
+
+func (propertyDefinitionId PropertyDefinitionId) matches(identifier string) bool {
+	return string(propertyDefinitionId.Id) == identifier || slices.Contains(propertyDefinitionId.Aliases, identifier)
+}
+
+// mapKeys returns the keys of 'input' sorted so API calls are made in a stable order
+func mapKeys[T any](input map[string]T) []string {
+	output := make([]string, 0, len(input))
+	for key := range input {
+		output = append(output, key)
+	}
+	slices.Sort(output)
+	return output
+}
+
+// jsonEqual compares two json documents semantically, ex: key order and whitespace are ignored
+func jsonEqual(a, b JsonString) bool {
+	var left, right any
+	if err := json.Unmarshal([]byte(a), &left); err != nil {
+		return a == b
+	}
+	if err := json.Unmarshal([]byte(b), &right); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
@@ -378,3 +378,81 @@ func TestGetServiceProperties(t *testing.T) {
 	autopilot.Equals(t, expectedPropsPageOne[1].Value, result[1].Value)
 	autopilot.Equals(t, expectedPropsPageTwo[0].Value, result[2].Value)
 }
+
+func TestReconcileProperties(t *testing.T) {
+	// Arrange
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServicePropertiesList($after:String!$first:Int!$service:ID!){account{service(id: $service){properties(after: $after, first: $first){nodes{definition{id,aliases},locked,owner{... on Service{id,aliases}},validationErrors{message,path},value},{{ template "pagination_request" }}}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{"data":{"account":{"service":{"properties":{"nodes":[
+			{"definition":{"id":"{{ template "id2_string" }}","aliases":["team_size"]},"locked":false,"validationErrors":[],"value":"3"},
+			{"definition":{"id":"{{ template "id3_string" }}","aliases":["config"]},"locked":false,"validationErrors":[],"value":"{\"b\": 2, \"a\": 1}"},
+			{"definition":{"id":"{{ template "id4_string" }}","aliases":["is_beta"]},"locked":false,"validationErrors":[],"value":"true"},
+			{"definition":{"id":"{{ template "id1_string" }}","aliases":["compliance"]},"locked":true,"validationErrors":[],"value":"\"sox\""}
+		],{{ template "no_pagination_response" }}}}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation PropertyUnassign($definition:IdentifierInput!$owner:IdentifierInput!){propertyUnassign(owner: $owner, definition: $definition){errors{message,path}}}`,
+		`{"owner":{ {{ template "id1" }} },"definition":{ {{ template "id4" }} }}`,
+		`{"data":{"propertyUnassign":{"errors":[]}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation PropertyAssign($input:PropertyInput!){propertyAssign(input: $input){property{definition{id,aliases},locked,owner{... on Service{id,aliases}},validationErrors{message,path},value},errors{message,path}}}`,
+		`{"input":{"owner":{ {{ template "id1" }} },"definition":{"alias":"language"},"value":"\"cobol\""}}`,
+		`{"data":{"propertyAssign":{"property":{"definition":{"id":"Z2lkOi8vNTU1","aliases":["language"]},"locked":false,"owner":{ {{ template "id1" }} },"validationErrors":[{"message":"value is not one of the allowed values","path":["value"]}],"value":"\"cobol\""},"errors":[]}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "property/reconcile", requests...)
+	// Act
+	result, err := client.ReconcileProperties(&service, map[string]any{
+		"team_size": 3,
+		"config":    map[string]any{"a": 1, "b": 2},
+		"language":  "cobol",
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Assigned))
+	autopilot.Equals(t, []string{"language"}, result.Assigned[0].Definition.Aliases)
+	autopilot.Equals(t, []ol.PropertyDefinitionId{{Id: id4, Aliases: []string{"is_beta"}}}, result.Unassigned)
+	autopilot.Equals(t, 1, len(result.Locked))
+	autopilot.Equals(t, []string{"compliance"}, result.Locked[0].Definition.Aliases)
+	autopilot.Equals(t, 1, len(result.Invalid))
+	autopilot.Equals(t, "value is not one of the allowed values", result.Invalid[0].ValidationErrors[0].Message)
+}
+
+func TestReconcilePropertiesRefetchesLoadedProperties(t *testing.T) {
+	// Arrange
+	stale := ol.JsonString("2")
+	service := ol.Service{
+		ServiceId: ol.ServiceId{Id: id1},
+		Properties: &ol.ServicePropertiesConnection{Nodes: []ol.Property{
+			{Definition: ol.PropertyDefinitionId{Id: id2, Aliases: []string{"team_size"}}, Value: &stale},
+		}},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServicePropertiesList($after:String!$first:Int!$service:ID!){account{service(id: $service){properties(after: $after, first: $first){nodes{definition{id,aliases},locked,owner{... on Service{id,aliases}},validationErrors{message,path},value},{{ template "pagination_request" }}}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{"data":{"account":{"service":{"properties":{"nodes":[
+			{"definition":{"id":"{{ template "id2_string" }}","aliases":["team_size"]},"locked":false,"validationErrors":[],"value":"3"}
+		],{{ template "no_pagination_response" }}}}}}}`,
+	)
+
+	client := BestTestClient(t, "property/reconcile_refetch", testRequestOne)
+	// Act
+	result, err := client.ReconcileProperties(&service, map[string]any{"team_size": 3})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 0, len(result.Assigned))
+	autopilot.Equals(t, 1, len(service.Properties.Nodes))
+}
+
+func TestReconcilePropertiesInvalidService(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "property/reconcile_invalid_service")
+	// Act
+	_, err := client.ReconcileProperties(&ol.Service{}, map[string]any{"language": "go"})
+	// Assert
+	autopilot.Equals(t, "unable to reconcile properties, invalid service id: ''", err.Error())
+}
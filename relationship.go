@@ -0,0 +1,197 @@
+package opslevel
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RelationshipResource is a resource on either end of a relationship, Typename tells which fragment is populated
+type RelationshipResource struct {
+	Typename               string     `graphql:"__typename" json:"__typename"`
+	Domain                 DomainId   `graphql:"... on Domain" json:"-"`
+	InfrastructureResource Identifier `graphql:"... on InfrastructureResource" json:"-"`
+	Service                ServiceId  `graphql:"... on Service" json:"-"`
+	System                 SystemId   `graphql:"... on System" json:"-"`
+}
+
+type RelationshipNode struct {
+	Id     ID                   `graphql:"id"`
+	Source RelationshipResource `graphql:"source"`
+	Target RelationshipResource `graphql:"target"`
+	Type   RelationshipTypeEnum `graphql:"type"`
+}
+
+type RelatedResourceEdge struct {
+	Node             RelationshipResource                `graphql:"node"`
+	RelationshipId   ID                                  `graphql:"relationshipId"`
+	RelationshipType RelatedResourceRelationshipTypeEnum `graphql:"relationshipType"`
+}
+
+type RelatedResourceConnection struct {
+	Edges      []RelatedResourceEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+func (relationshipResource *RelationshipResource) Id() ID {
+	switch relationshipResource.Typename {
+	case "Domain":
+		return relationshipResource.Domain.Id
+	case "InfrastructureResource":
+		return relationshipResource.InfrastructureResource.Id
+	case "Service":
+		return relationshipResource.Service.Id
+	case "System":
+		return relationshipResource.System.Id
+	}
+	return ""
+}
+
+func (relationshipResource *RelationshipResource) Aliases() []string {
+	switch relationshipResource.Typename {
+	case "Domain":
+		return relationshipResource.Domain.Aliases
+	case "InfrastructureResource":
+		return relationshipResource.InfrastructureResource.Aliases
+	case "Service":
+		return relationshipResource.Service.Aliases
+	case "System":
+		return relationshipResource.System.Aliases
+	}
+	return nil
+}
+
+// Filter returns the edges with the given relationship type
+func (relatedResourceConnection *RelatedResourceConnection) Filter(relationshipType RelatedResourceRelationshipTypeEnum) []RelatedResourceEdge {
+	var output []RelatedResourceEdge
+	for _, edge := range relatedResourceConnection.Edges {
+		if edge.RelationshipType == relationshipType {
+			output = append(output, edge)
+		}
+	}
+	return output
+}
+
+func (client *Client) CreateRelationship(input RelationshipDefinition) (*RelationshipNode, error) {
+	var m struct {
+		Payload struct {
+			Relationship RelationshipNode
+			Errors       []OpsLevelErrors
+		} `graphql:"relationshipCreate(relationshipDefinition: $input)"`
+	}
+	if !slices.Contains(AllRelationshipTypeEnum, string(input.Type)) {
+		return nil, fmt.Errorf("relationship type '%s' must be one of %v", input.Type, AllRelationshipTypeEnum)
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("RelationshipCreate"))
+	return &m.Payload.Relationship, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) DeleteRelationship(id ID) error {
+	var m struct {
+		Payload struct {
+			Id     ID               `graphql:"deletedId"`
+			Errors []OpsLevelErrors `graphql:"errors"`
+		} `graphql:"relationshipDelete(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": DeleteInput{Id: id},
+	}
+	err := client.Mutate(&m, v, WithName("RelationshipDelete"))
+	return HandleErrors(err, m.Payload.Errors)
+}
+
+func (service *Service) GetRelatedResources(client *Client, variables *PayloadVariables) (*RelatedResourceConnection, error) {
+	var q struct {
+		Account struct {
+			Service struct {
+				RelatedResources RelatedResourceConnection `graphql:"relatedResources(after: $after, first: $first)"`
+			} `graphql:"service(id: $service)"`
+		}
+	}
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to get RelatedResources, invalid service id: '%s'", service.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["service"] = service.Id
+	if err := client.Query(&q, *variables, WithName("ServiceRelatedResourcesList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Service.RelatedResources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Service.RelatedResources.PageInfo.End
+		resp, err := service.GetRelatedResources(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Service.RelatedResources.Edges = append(q.Account.Service.RelatedResources.Edges, resp.Edges...)
+		q.Account.Service.RelatedResources.PageInfo = resp.PageInfo
+		q.Account.Service.RelatedResources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Service.RelatedResources, nil
+}
+
+func (systemId *SystemId) GetRelatedResources(client *Client, variables *PayloadVariables) (*RelatedResourceConnection, error) {
+	var q struct {
+		Account struct {
+			System struct {
+				RelatedResources RelatedResourceConnection `graphql:"relatedResources(after: $after, first: $first)"`
+			} `graphql:"system(input: $system)"`
+		}
+	}
+	if systemId.Id == "" {
+		return nil, fmt.Errorf("unable to get RelatedResources, invalid system id: '%s'", systemId.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["system"] = *NewIdentifier(string(systemId.Id))
+	if err := client.Query(&q, *variables, WithName("SystemRelatedResourcesList")); err != nil {
+		return nil, err
+	}
+	for q.Account.System.RelatedResources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.System.RelatedResources.PageInfo.End
+		resp, err := systemId.GetRelatedResources(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.System.RelatedResources.Edges = append(q.Account.System.RelatedResources.Edges, resp.Edges...)
+		q.Account.System.RelatedResources.PageInfo = resp.PageInfo
+		q.Account.System.RelatedResources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.System.RelatedResources, nil
+}
+
+func (infrastructureResource *InfrastructureResource) GetRelatedResources(client *Client, variables *PayloadVariables) (*RelatedResourceConnection, error) {
+	var q struct {
+		Account struct {
+			InfrastructureResource struct {
+				RelatedResources RelatedResourceConnection `graphql:"relatedResources(after: $after, first: $first)"`
+			} `graphql:"infrastructureResource(input: $infrastructureResource)"`
+		}
+	}
+	if infrastructureResource.Id == "" {
+		return nil, fmt.Errorf("unable to get RelatedResources, invalid InfrastructureResource id: '%s'", infrastructureResource.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["infrastructureResource"] = *NewIdentifier(infrastructureResource.Id)
+	if err := client.Query(&q, *variables, WithName("InfrastructureResourceRelatedResourcesList")); err != nil {
+		return nil, err
+	}
+	for q.Account.InfrastructureResource.RelatedResources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.InfrastructureResource.RelatedResources.PageInfo.End
+		resp, err := infrastructureResource.GetRelatedResources(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.InfrastructureResource.RelatedResources.Edges = append(q.Account.InfrastructureResource.RelatedResources.Edges, resp.Edges...)
+		q.Account.InfrastructureResource.RelatedResources.PageInfo = resp.PageInfo
+		q.Account.InfrastructureResource.RelatedResources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.InfrastructureResource.RelatedResources, nil
+}
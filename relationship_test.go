@@ -0,0 +1,128 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestCreateRelationship(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation RelationshipCreate($input:RelationshipDefinition!){relationshipCreate(relationshipDefinition: $input){relationship{id,source{ {{- template "relationship_resource_request" }}},target{ {{- template "relationship_resource_request" }}},type},errors{message,path}}}`,
+		`{"input": { "source": { "id": "{{ template "id2_string" }}" }, "target": { "alias": "example_system" }, "type": "belongs_to" }}`,
+		`{"data": { "relationshipCreate": { "relationship": {{ template "relationship_1" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "relationship/create", testRequest)
+	// Act
+	result, err := client.CreateRelationship(ol.RelationshipDefinition{
+		Source: *ol.NewIdentifier(string(id2)),
+		Target: *ol.NewIdentifier("example_system"),
+		Type:   ol.RelationshipTypeEnumBelongsTo,
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id1, result.Id)
+	autopilot.Equals(t, "Service", result.Source.Typename)
+	autopilot.Equals(t, id2, result.Source.Id())
+	autopilot.Equals(t, []string{"example_system"}, result.Target.Aliases())
+	autopilot.Equals(t, ol.RelationshipTypeEnumBelongsTo, result.Type)
+}
+
+func TestCreateRelationshipInvalidType(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "relationship/create_invalid_type")
+	// Act
+	_, err := client.CreateRelationship(ol.RelationshipDefinition{
+		Source: *ol.NewIdentifier(string(id2)),
+		Target: *ol.NewIdentifier(string(id3)),
+		Type:   ol.RelationshipTypeEnum("contains"),
+	})
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown relationship type")
+}
+
+func TestDeleteRelationship(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation RelationshipDelete($input:DeleteInput!){relationshipDelete(input: $input){deletedId,errors{message,path}}}`,
+		`{"input": { {{ template "id1" }} }}`,
+		`{"data": { "relationshipDelete": { "deletedId": "{{ template "id1_string" }}", "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "relationship/delete", testRequest)
+	// Act
+	err := client.DeleteRelationship(id1)
+	// Assert
+	autopilot.Ok(t, err)
+}
+
+func TestServiceGetRelatedResources(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceRelatedResourcesList($after:String!$first:Int!$service:ID!){account{service(id: $service){ {{- template "related_resources_request" }}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id2_string" }}" }`,
+		`{ "data": { "account": { "service": { "relatedResources": { "edges": [ {{ template "related_resource_1" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query ServiceRelatedResourcesList($after:String!$first:Int!$service:ID!){account{service(id: $service){ {{- template "related_resources_request" }}}}}`,
+		`{ {{ template "second_page_variables" }}, "service": "{{ template "id2_string" }}" }`,
+		`{ "data": { "account": { "service": { "relatedResources": { "edges": [ {{ template "related_resource_2" }} ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "relationship/service_related_resources", requests...)
+	service := ol.Service{
+		ServiceId: ol.ServiceId{
+			Id: id2,
+		},
+	}
+	// Act
+	resp, err := service.GetRelatedResources(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, resp.TotalCount)
+	autopilot.Equals(t, "System", resp.Edges[0].Node.Typename)
+	autopilot.Equals(t, id3, resp.Edges[0].Node.Id())
+	autopilot.Equals(t, "InfrastructureResource", resp.Edges[1].Node.Typename)
+	autopilot.Equals(t, []string{"example_database"}, resp.Edges[1].Node.Aliases())
+	dependencies := resp.Filter(ol.RelatedResourceRelationshipTypeEnumDependsOn)
+	autopilot.Equals(t, 1, len(dependencies))
+	autopilot.Equals(t, id2, dependencies[0].RelationshipId)
+}
+
+func TestSystemGetRelatedResources(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query SystemRelatedResourcesList($after:String!$first:Int!$system:IdentifierInput!){account{system(input: $system){ {{- template "related_resources_request" }}}}}`,
+		`{ {{ template "first_page_variables" }}, "system": { "id": "{{ template "id3_string" }}" }}`,
+		`{ "data": { "account": { "system": { "relatedResources": { "edges": [ {{ template "related_resource_2" }} ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}}`,
+	)
+	client := BestTestClient(t, "relationship/system_related_resources", testRequest)
+	system := ol.SystemId{Id: id3}
+	// Act
+	resp, err := system.GetRelatedResources(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, resp.TotalCount)
+	autopilot.Equals(t, id4, resp.Edges[0].Node.Id())
+	autopilot.Equals(t, ol.RelatedResourceRelationshipTypeEnumDependsOn, resp.Edges[0].RelationshipType)
+}
+
+func TestInfrastructureResourceGetRelatedResources(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query InfrastructureResourceRelatedResourcesList($after:String!$first:Int!$infrastructureResource:IdentifierInput!){account{infrastructureResource(input: $infrastructureResource){ {{- template "related_resources_request" }}}}}`,
+		`{ {{ template "first_page_variables" }}, "infrastructureResource": { "id": "{{ template "id4_string" }}" }}`,
+		`{ "data": { "account": { "infrastructureResource": { "relatedResources": { "edges": [ {{ template "related_resource_1" }} ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}}`,
+	)
+	client := BestTestClient(t, "relationship/infrastructure_related_resources", testRequest)
+	infrastructureResource := ol.InfrastructureResource{Id: string(id4)}
+	// Act
+	resp, err := infrastructureResource.GetRelatedResources(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, resp.TotalCount)
+	autopilot.Equals(t, "System", resp.Edges[0].Node.Typename)
+	autopilot.Equals(t, []string{"example_system"}, resp.Edges[0].Node.Aliases())
+}
@@ -1,8 +1,11 @@
 package opslevel
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"slices"
+	"strings"
 
 	"github.com/relvacode/iso8601"
 )
@@ -65,6 +68,15 @@ type RepositoryConnection struct {
 	VisibleCount      int
 }
 
+// RepositoryMatcher selects repositories for bulk visibility updates, unset fields match every repository
+type RepositoryMatcher struct {
+	Organization string         // Matched case insensitively
+	Name         *regexp.Regexp // Matched against the repository name
+	Archived     *bool
+	Forked       *bool
+	Private      *bool
+}
+
 type RepositoryServiceEdge struct {
 	AtRoot              bool
 	Node                ServiceId
@@ -97,6 +109,56 @@ func (repository *Repository) ResourceType() TaggableResource {
 	return TaggableResourceRepository
 }
 
+func (repository *Repository) IsArchived() bool {
+	return !repository.ArchivedAt.IsZero()
+}
+
+// HasServices is true when the repository is linked to at least one service
+func (repository *Repository) HasServices() bool {
+	return repository.Services != nil && (repository.Services.TotalCount > 0 || len(repository.Services.Edges) > 0)
+}
+
+func (repositoryMatcher RepositoryMatcher) Matches(repository Repository) bool {
+	if repositoryMatcher.Organization != "" && !strings.EqualFold(repositoryMatcher.Organization, repository.Organization) {
+		return false
+	}
+	if repositoryMatcher.Name != nil && !repositoryMatcher.Name.MatchString(repository.Name) {
+		return false
+	}
+	if repositoryMatcher.Archived != nil && *repositoryMatcher.Archived != repository.IsArchived() {
+		return false
+	}
+	if repositoryMatcher.Forked != nil && *repositoryMatcher.Forked != repository.Forked {
+		return false
+	}
+	if repositoryMatcher.Private != nil && *repositoryMatcher.Private != repository.Private {
+		return false
+	}
+	return true
+}
+
+// Filter returns the repositories that match 'repositoryMatcher'
+func (repositoryConnection *RepositoryConnection) Filter(repositoryMatcher RepositoryMatcher) []Repository {
+	var output []Repository
+	for _, node := range repositoryConnection.Nodes {
+		if repositoryMatcher.Matches(node) {
+			output = append(output, node)
+		}
+	}
+	return output
+}
+
+// WithoutServices returns the repositories that are not linked to any service
+func (repositoryConnection *RepositoryConnection) WithoutServices() []Repository {
+	var output []Repository
+	for _, node := range repositoryConnection.Nodes {
+		if !node.HasServices() {
+			output = append(output, node)
+		}
+	}
+	return output
+}
+
 func (repository *Repository) GetService(service ID, directory string) *ServiceRepository {
 	for _, edge := range repository.Services.Edges {
 		for _, connection := range edge.ServiceRepositories {
@@ -300,6 +362,49 @@ func (client *Client) ListRepositories(variables *PayloadVariables) (*Repository
 	return &q.Account.Repositories, nil
 }
 
+func (client *Client) ListRepositoriesWithVisibility(visible bool, variables *PayloadVariables) (*RepositoryConnection, error) {
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["visible"] = visible
+	return client.ListRepositories(variables)
+}
+
+func (client *Client) ListHiddenRepositories(variables *PayloadVariables) (*RepositoryConnection, error) {
+	return client.ListRepositoriesWithVisibility(false, variables)
+}
+
+// ListRepositoriesWithoutServices returns the visible repositories that are not linked to any service
+func (client *Client) ListRepositoriesWithoutServices() ([]Repository, error) {
+	repositories, err := client.ListRepositories(nil)
+	if err != nil {
+		return nil, err
+	}
+	return repositories.WithoutServices(), nil
+}
+
+// SetRepositoriesVisibility hides or unhides every repository matching 'repositoryMatcher'
+// and returns the repositories that were updated
+func (client *Client) SetRepositoriesVisibility(repositoryMatcher RepositoryMatcher, visible bool) ([]Repository, error) {
+	// Only repositories with the opposite visibility need to be updated
+	repositories, err := client.ListRepositoriesWithVisibility(!visible, nil)
+	if err != nil {
+		return nil, err
+	}
+	var output []Repository
+	for _, repository := range repositories.Filter(repositoryMatcher) {
+		updated, err := client.UpdateRepository(RepositoryUpdateInput{
+			Id:      repository.Id,
+			Visible: RefOf(visible),
+		})
+		if err != nil {
+			return output, err
+		}
+		output = append(output, *updated)
+	}
+	return output, nil
+}
+
 func (client *Client) ListRepositoriesWithTier(tier string, variables *PayloadVariables) (*RepositoryConnection, error) {
 	var q struct {
 		Account struct {
@@ -373,3 +478,181 @@ func (client *Client) DeleteServiceRepository(id ID) error {
 	err := client.Mutate(&m, v, WithName("ServiceRepositoryDelete"))
 	return HandleErrors(err, m.Payload.Errors)
 }
+
+// ServiceRepositorySpec is the desired link between a service and a directory of a repository
+type ServiceRepositorySpec struct {
+	Repository    string `json:"repository" yaml:"repository"`                           // The alias or id of the repository
+	BaseDirectory string `json:"baseDirectory,omitempty" yaml:"baseDirectory,omitempty"` // Defaults to the repository root
+	DisplayName   string `json:"displayName,omitempty" yaml:"displayName,omitempty"`     // Left unmanaged when empty
+}
+
+// ServiceRepositoryReconcileResult holds the service repository links changed by ReconcileServiceRepositories
+type ServiceRepositoryReconcileResult struct {
+	Created []ServiceRepository
+	Updated []ServiceRepository
+	Deleted []ServiceRepository
+	Default *ServiceRepository // The default link after reconciling, nil when OpsLevel has to pick a new one
+}
+
+// ReconcileServiceRepositories links the service to exactly the desired repository directories.
+//
+// Links of a repository whose base directory changed are updated in place rather than recreated,
+// preferring the service's default link so the default repository selection survives the change.
+// The API does not allow choosing the default link, so when it is deleted Default is left nil and
+// the service has to be fetched again to read the one OpsLevel picked.
+func (client *Client) ReconcileServiceRepositories(service *Service, desired []ServiceRepositorySpec) (*ServiceRepositoryReconcileResult, error) {
+	for i, spec := range desired {
+		if spec.Repository == "" {
+			return nil, fmt.Errorf("repository of the service repository at index %d must not be empty", i)
+		}
+		if slices.ContainsFunc(desired[:i], func(other ServiceRepositorySpec) bool {
+			return other.Repository == spec.Repository && other.baseDirectory() == spec.baseDirectory()
+		}) {
+			return nil, fmt.Errorf("repository '%s' with base directory '%s' is wanted more than once", spec.Repository, spec.baseDirectory())
+		}
+	}
+	if service.Repositories == nil || service.Repositories.PageInfo.HasNextPage {
+		service.Repositories = nil
+		if _, err := service.GetRepositories(client, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var existing []ServiceRepository
+	for _, edge := range service.Repositories.Edges {
+		for _, serviceRepository := range edge.ServiceRepositories {
+			serviceRepository.Repository = edge.Node
+			existing = append(existing, serviceRepository)
+		}
+	}
+	isDefault := func(serviceRepository ServiceRepository) bool {
+		return service.Repository != nil && service.Repository.Id == serviceRepository.Id
+	}
+
+	// Pair every desired spec with an existing link, first by exact directory then by repository
+	paired := make([]int, len(desired))
+	claimed := make([]bool, len(existing))
+	for i, spec := range desired {
+		paired[i] = -1
+		for j, serviceRepository := range existing {
+			if !claimed[j] && spec.matches(serviceRepository) {
+				paired[i], claimed[j] = j, true
+				break
+			}
+		}
+	}
+	for i, spec := range desired {
+		if paired[i] >= 0 {
+			continue
+		}
+		for j, serviceRepository := range existing {
+			if claimed[j] || !spec.matchesRepository(serviceRepository.Repository) {
+				continue
+			}
+			if paired[i] < 0 || isDefault(serviceRepository) {
+				paired[i] = j
+			}
+		}
+		if paired[i] >= 0 {
+			claimed[paired[i]] = true
+		}
+	}
+
+	var allErrors error
+	result := &ServiceRepositoryReconcileResult{}
+	var kept []ServiceRepository
+	for j, serviceRepository := range existing {
+		if claimed[j] {
+			continue
+		}
+		if err := client.DeleteServiceRepository(serviceRepository.Id); err != nil {
+			allErrors = errors.Join(allErrors, err)
+			kept = append(kept, serviceRepository)
+			continue
+		}
+		result.Deleted = append(result.Deleted, serviceRepository)
+	}
+	for i, spec := range desired {
+		if paired[i] < 0 {
+			continue
+		}
+		serviceRepository := existing[paired[i]]
+		input := ServiceRepositoryUpdateInput{Id: serviceRepository.Id}
+		if strings.Trim(serviceRepository.BaseDirectory, "/") != spec.baseDirectory() {
+			input.BaseDirectory = RefOf(spec.baseDirectory())
+		}
+		if spec.DisplayName != "" && serviceRepository.DisplayName != spec.DisplayName {
+			input.DisplayName = RefOf(spec.DisplayName)
+		}
+		if input.BaseDirectory == nil && input.DisplayName == nil {
+			kept = append(kept, serviceRepository)
+			continue
+		}
+		updated, err := client.UpdateServiceRepository(input)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			kept = append(kept, serviceRepository)
+			continue
+		}
+		updated.Repository = serviceRepository.Repository
+		result.Updated = append(result.Updated, *updated)
+		kept = append(kept, *updated)
+	}
+	for i, spec := range desired {
+		if paired[i] >= 0 {
+			continue
+		}
+		created, err := client.CreateServiceRepository(ServiceRepositoryCreateInput{
+			Service:       *NewIdentifier(string(service.Id)),
+			Repository:    *NewIdentifier(spec.Repository),
+			BaseDirectory: RefOf(spec.baseDirectory()),
+			DisplayName:   nilIfEmpty(spec.DisplayName),
+		})
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		result.Created = append(result.Created, *created)
+		kept = append(kept, *created)
+	}
+
+	if index := slices.IndexFunc(kept, isDefault); index >= 0 {
+		result.Default = &kept[index]
+	} else if service.Repository == nil && len(kept) == 1 {
+		// The first link of a service becomes its default
+		result.Default = &kept[0]
+	}
+	service.Repository = result.Default
+	service.Repositories = serviceRepositoryConnection(kept)
+	return result, allErrors
+}
+
+// serviceRepositoryConnection groups service repositories into edges by repository
+func serviceRepositoryConnection(serviceRepositories []ServiceRepository) *ServiceRepositoryConnection {
+	connection := &ServiceRepositoryConnection{}
+	for _, serviceRepository := range serviceRepositories {
+		index := slices.IndexFunc(connection.Edges, func(edge ServiceRepositoryEdge) bool {
+			return edge.Node.Id == serviceRepository.Repository.Id
+		})
+		if index < 0 {
+			connection.Edges = append(connection.Edges, ServiceRepositoryEdge{Node: serviceRepository.Repository})
+			index = len(connection.Edges) - 1
+		}
+		connection.Edges[index].ServiceRepositories = append(connection.Edges[index].ServiceRepositories, serviceRepository)
+	}
+	connection.TotalCount = len(connection.Edges)
+	return connection
+}
+
+// baseDirectory is returned by the API without leading and trailing slashes
+func (spec ServiceRepositorySpec) baseDirectory() string {
+	return strings.Trim(spec.BaseDirectory, "/")
+}
+
+func (spec ServiceRepositorySpec) matchesRepository(repository RepositoryId) bool {
+	return spec.Repository == string(repository.Id) || spec.Repository == repository.DefaultAlias
+}
+
+func (spec ServiceRepositorySpec) matches(serviceRepository ServiceRepository) bool {
+	return spec.matchesRepository(serviceRepository.Repository) && spec.baseDirectory() == strings.Trim(serviceRepository.BaseDirectory, "/")
+}
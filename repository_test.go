@@ -1,7 +1,9 @@
 package opslevel_test
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
 	ol "github.com/opslevel/opslevel-go/v2024"
 
@@ -550,3 +552,147 @@ func TestGetTags(t *testing.T) {
 	autopilot.Equals(t, "env", result[3].Key)
 	autopilot.Equals(t, "staging", result[3].Value)
 }
+
+func TestListHiddenRepositories(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query RepositoryList($after:String!$first:Int!$visible:Boolean!){account{repositories(after: $after, first: $first, visible: $visible){hiddenCount,nodes{ {{- template "repository_request" -}} },organizationCount,ownedCount,{{ template "pagination_request" }},totalCount,visibleCount}}}`,
+		`{ {{ template "first_page_variables" }}, "visible": false }`,
+		`{ "data": { "account": { "repositories": { "hiddenCount": 2, "nodes": [ {{ template "repository_hidden_1" }}, {{ template "repository_hidden_2" }} ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+
+	client := BestTestClient(t, "repositories/list_hidden", testRequest)
+	// Act
+	resp, err := client.ListHiddenRepositories(nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, resp.HiddenCount)
+	autopilot.Equals(t, false, resp.Nodes[0].Visible)
+	autopilot.Equals(t, true, resp.Nodes[0].IsArchived())
+	autopilot.Equals(t, 1, len(resp.WithoutServices()))
+	autopilot.Equals(t, "legacy-billing", resp.WithoutServices()[0].Name)
+}
+
+func TestRepositoryMatcher(t *testing.T) {
+	archived := ol.Repository{Name: "legacy-billing", Organization: "OpsLevel", Private: true}
+	archived.ArchivedAt.Time = time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	forked := ol.Repository{Name: "sandbox-experiments", Organization: "OpsLevel", Forked: true}
+	testcases := map[string]struct {
+		matcher  ol.RepositoryMatcher
+		expected []string
+	}{
+		"empty matches all":    {ol.RepositoryMatcher{}, []string{"legacy-billing", "sandbox-experiments"}},
+		"organization":         {ol.RepositoryMatcher{Organization: "opslevel"}, []string{"legacy-billing", "sandbox-experiments"}},
+		"other organization":   {ol.RepositoryMatcher{Organization: "rocktavious"}, nil},
+		"name pattern":         {ol.RepositoryMatcher{Name: regexp.MustCompile(`^sandbox-`)}, []string{"sandbox-experiments"}},
+		"archived":             {ol.RepositoryMatcher{Archived: ol.RefOf(true)}, []string{"legacy-billing"}},
+		"not forked":           {ol.RepositoryMatcher{Forked: ol.RefOf(false)}, []string{"legacy-billing"}},
+		"private and archived": {ol.RepositoryMatcher{Private: ol.RefOf(true), Archived: ol.RefOf(false)}, nil},
+	}
+	connection := ol.RepositoryConnection{Nodes: []ol.Repository{archived, forked}}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			var result []string
+			for _, repository := range connection.Filter(tc.matcher) {
+				result = append(result, repository.Name)
+			}
+			// Assert
+			autopilot.Equals(t, tc.expected, result)
+		})
+	}
+}
+
+func TestSetRepositoriesVisibility(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query RepositoryList($after:String!$first:Int!$visible:Boolean!){account{repositories(after: $after, first: $first, visible: $visible){hiddenCount,nodes{ {{- template "repository_request" -}} },organizationCount,ownedCount,{{ template "pagination_request" }},totalCount,visibleCount}}}`,
+		`{ {{ template "first_page_variables" }}, "visible": false }`,
+		`{ "data": { "account": { "repositories": { "hiddenCount": 2, "nodes": [ {{ template "repository_hidden_1" }}, {{ template "repository_hidden_2" }} ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation RepositoryUpdate($input:RepositoryUpdateInput!){repositoryUpdate(input: $input){repository{ {{- template "repository_request" -}} },errors{message,path}}}`,
+		`{"input": { {{ template "id3" }}, "visible": true }}`,
+		`{"data": { "repositoryUpdate": { "repository": { {{ template "id3" }}, "name": "sandbox-experiments", "visible": true }, "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "repositories/set_visibility", requests...)
+	// Act
+	result, err := client.SetRepositoriesVisibility(ol.RepositoryMatcher{Forked: ol.RefOf(true)}, true)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result))
+	autopilot.Equals(t, id3, result[0].Id)
+	autopilot.Equals(t, true, result[0].Visible)
+}
+
+func TestReconcileServiceRepositories(t *testing.T) {
+	// Arrange
+	service := ol.Service{
+		ServiceId:  ol.ServiceId{Id: id1},
+		Repository: &ol.ServiceRepository{Id: id2},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceRepositoriesList($after:String!$first:Int!$service:ID!){account{service(id: $service){repos(after: $after, first: $first){edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{"data": {"account": { "service": { "repos": { "edges": [
+			{ "node": { "id": "Z2lkOi8vMTAx", "defaultAlias": "github.com:org/mono" }, "serviceRepositories": [
+				{ "baseDirectory": "services/checkout", "displayName": "org/mono", {{ template "id2" }} },
+				{ "baseDirectory": "libs/shared", "displayName": "org/mono", {{ template "id3" }} }
+			] },
+			{ "node": { "id": "Z2lkOi8vMTAy", "defaultAlias": "github.com:org/legacy" }, "serviceRepositories": [
+				{ "baseDirectory": "", "displayName": "org/legacy", {{ template "id4" }} }
+			] }
+		], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation ServiceRepositoryDelete($input:DeleteInput!){serviceRepositoryDelete(input: $input){deletedId,errors{message,path}}}`,
+		`{ "input": { {{ template "id4" }} } }`,
+		`{"data": { "serviceRepositoryDelete": { "deletedId": "{{ template "id4_string" }}", "errors": [] }}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation ServiceRepositoryUpdate($input:ServiceRepositoryUpdateInput!){serviceRepositoryUpdate(input: $input){serviceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},errors{message,path}}}`,
+		`{ "input": { {{ template "id2" }}, "baseDirectory": "services/checkout-v2", "displayName": "Checkout" } }`,
+		`{"data": { "serviceRepositoryUpdate": { "serviceRepository": { "baseDirectory": "services/checkout-v2", "displayName": "Checkout", {{ template "id2" }}, "repository": { "id": "Z2lkOi8vMTAx", "defaultAlias": "github.com:org/mono" }, "service": { {{ template "id1" }}, "aliases": [] } }, "errors": [] }}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`mutation ServiceRepositoryCreate($input:ServiceRepositoryCreateInput!){serviceRepositoryCreate(input: $input){serviceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},errors{message,path}}}`,
+		`{ "input": { "service": { {{ template "id1" }} }, "repository": { "alias": "github.com:org/docs" }, "baseDirectory": "" } }`,
+		`{"data": { "serviceRepositoryCreate": { "serviceRepository": { "baseDirectory": "", "displayName": "org/docs", "id": "Z2lkOi8vMjAx", "repository": { "id": "Z2lkOi8vMTAz", "defaultAlias": "github.com:org/docs" }, "service": { {{ template "id1" }}, "aliases": [] } }, "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree, testRequestFour}
+
+	client := BestTestClient(t, "repository/reconcile_service_repositories", requests...)
+	// Act
+	result, err := client.ReconcileServiceRepositories(&service, []ol.ServiceRepositorySpec{
+		{Repository: "github.com:org/mono", BaseDirectory: "/services/checkout-v2/", DisplayName: "Checkout"},
+		{Repository: "github.com:org/mono", BaseDirectory: "libs/shared"},
+		{Repository: "github.com:org/docs"},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Created))
+	autopilot.Equals(t, "github.com:org/docs", result.Created[0].Repository.DefaultAlias)
+	autopilot.Equals(t, 1, len(result.Updated))
+	autopilot.Equals(t, "services/checkout-v2", result.Updated[0].BaseDirectory)
+	autopilot.Equals(t, 1, len(result.Deleted))
+	autopilot.Equals(t, id4, result.Deleted[0].Id)
+	autopilot.Equals(t, id2, result.Default.Id)
+	autopilot.Equals(t, "Checkout", service.Repository.DisplayName)
+	autopilot.Equals(t, 2, service.Repositories.TotalCount)
+	autopilot.Equals(t, 2, len(service.Repositories.Edges[0].ServiceRepositories))
+}
+
+func TestReconcileServiceRepositoriesDuplicate(t *testing.T) {
+	// Arrange
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	client := BestTestClient(t, "repository/reconcile_service_repositories_duplicate")
+	// Act
+	_, err := client.ReconcileServiceRepositories(&service, []ol.ServiceRepositorySpec{
+		{Repository: "github.com:org/mono", BaseDirectory: "services/checkout"},
+		{Repository: "github.com:org/mono", BaseDirectory: "/services/checkout/"},
+	})
+	// Assert
+	autopilot.Equals(t, "repository 'github.com:org/mono' with base directory 'services/checkout' is wanted more than once", err.Error())
+}
@@ -3,6 +3,7 @@ package opslevel
 import (
 	"errors"
 	"fmt"
+	"slices"
 )
 
 type ScorecardId struct {
@@ -84,6 +85,39 @@ func (scorecard *Scorecard) ListCategories(client *Client, variables *PayloadVar
 	return &q.Account.Scorecard.Categories, nil
 }
 
+func (scorecard *Scorecard) ListChecks(client *Client, variables *PayloadVariables) (*CheckConnection, error) {
+	if scorecard.Id == "" {
+		return nil, fmt.Errorf("unable to get checks, invalid scorecard id: '%s'", scorecard.Id)
+	}
+	var q struct {
+		Account struct {
+			Scorecard struct {
+				Checks CheckConnection `graphql:"checks(after: $after, first: $first)"`
+			} `graphql:"scorecard(input: $scorecard)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["scorecard"] = *NewIdentifier(string(scorecard.Id))
+	if err := client.Query(&q, *variables, WithName("ScorecardCheckList")); err != nil {
+		return nil, err
+	}
+
+	for q.Account.Scorecard.Checks.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Scorecard.Checks.PageInfo.End
+		resp, err := scorecard.ListChecks(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Scorecard.Checks.Nodes = append(q.Account.Scorecard.Checks.Nodes, resp.Nodes...)
+		q.Account.Scorecard.Checks.PageInfo = resp.PageInfo
+		q.Account.Scorecard.Checks.TotalCount += resp.TotalCount
+	}
+
+	return &q.Account.Scorecard.Checks, nil
+}
+
 func (client *Client) CreateScorecard(input ScorecardInput) (*Scorecard, error) {
 	var m struct {
 		Payload struct {
@@ -139,6 +173,35 @@ func (client *Client) ListScorecards(variables *PayloadVariables) (*ScorecardCon
 	return &q.Account.Scorecards, nil
 }
 
+func (client *Client) ListScorecardsWithSort(sortBy ScorecardSortEnum, variables *PayloadVariables) (*ScorecardConnection, error) {
+	var q struct {
+		Account struct {
+			Scorecards ScorecardConnection `graphql:"scorecards(sortBy: $sortBy, after: $after, first: $first)"`
+		}
+	}
+	if !slices.Contains(AllScorecardSortEnum, string(sortBy)) {
+		return nil, fmt.Errorf("sortBy must be one of %v. Given: '%s'", AllScorecardSortEnum, sortBy)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["sortBy"] = sortBy
+	if err := client.Query(&q, *variables, WithName("ScorecardsListWithSort")); err != nil {
+		return nil, err
+	}
+	for q.Account.Scorecards.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Scorecards.PageInfo.End
+		resp, err := client.ListScorecardsWithSort(sortBy, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Scorecards.Nodes = append(q.Account.Scorecards.Nodes, resp.Nodes...)
+		q.Account.Scorecards.PageInfo = resp.PageInfo
+		q.Account.Scorecards.TotalCount = len(q.Account.Scorecards.Nodes)
+	}
+	return &q.Account.Scorecards, nil
+}
+
 func (client *Client) UpdateScorecard(identifier string, input ScorecardInput) (*Scorecard, error) {
 	var m struct {
 		Payload struct {
@@ -165,6 +165,25 @@ func TestListScorecards(t *testing.T) {
 	autopilot.Equals(t, 33, result[2].ServiceCount)
 }
 
+func TestListScorecardsWithSort(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`{{ template "scorecard_sorted_list_query" }}`,
+		`{ {{ template "first_page_variables" }}, "sortBy": "passingCheckFraction_ASC" }`,
+		`{ "data": { "account": { "scorecards": { "nodes": [ { {{ template "scorecard_2_response" }} }, { {{ template "scorecard_1_response" }} } ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+
+	client := BestTestClient(t, "scorecards/list_scorecards_with_sort", testRequest)
+	// Act
+	response, err := client.ListScorecardsWithSort(ol.ScorecardSortEnumPassingcheckfractionAsc, nil)
+	result := response.Nodes
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, "second scorecard", result[0].Name)
+	autopilot.Equals(t, "first scorecard", result[1].Name)
+}
+
 func TestListScorecardCategories(t *testing.T) {
 	// Arrange
 	testRequestOne := autopilot.NewTestRequest(
@@ -197,6 +216,35 @@ func TestListScorecardCategories(t *testing.T) {
 	autopilot.Equals(t, "ownership", result[1].Name)
 }
 
+func TestListScorecardChecks(t *testing.T) {
+	// Arrange
+	testRequestOne := autopilot.NewTestRequest(
+		`query ScorecardCheckList($after:String!$first:Int!$scorecard:IdentifierInput!){account{scorecard(input: $scorecard){checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "scorecard": { {{ template "id1" }} } }`,
+		`{ "data": { "account": { "scorecard": { "checks": { "nodes": [ { {{ template "common_check_response" }} } ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query ScorecardCheckList($after:String!$first:Int!$scorecard:IdentifierInput!){account{scorecard(input: $scorecard){checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}`,
+		`{ {{ template "second_page_variables" }}, "scorecard": { {{ template "id1" }} } }`,
+		`{ "data": { "account": { "scorecard": { "checks": { "nodes": [ { {{ template "metrics_tool_check" }} } ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "scorecard/checks", requests...)
+	// Act
+	scorecard := ol.Scorecard{
+		ScorecardId: ol.ScorecardId{
+			Id: id1,
+		},
+	}
+	resp, err := scorecard.ListChecks(client, nil)
+	result := resp.Nodes
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, resp.TotalCount)
+	autopilot.Equals(t, "Metrics Tool", result[1].Name)
+}
+
 func TestScorecardReconcileAliasesDeleteAll(t *testing.T) {
 	// Arrange
 	aliasesWanted := []string{}
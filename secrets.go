@@ -1,5 +1,10 @@
 package opslevel
 
+import (
+	"fmt"
+	"slices"
+)
+
 type Secret struct {
 	Alias      string     `json:"alias"`
 	ID         ID         `json:"id"`
@@ -53,6 +58,35 @@ func (client *Client) ListSecretsVaultsSecret(variables *PayloadVariables) (*Sec
 	return &q.Account.SecretsVaultsSecrets, nil
 }
 
+func (client *Client) ListSecretsVaultsSecretWithSort(sortBy VaultSecretsSortEnum, variables *PayloadVariables) (*SecretsVaultsSecretConnection, error) {
+	var q struct {
+		Account struct {
+			SecretsVaultsSecrets SecretsVaultsSecretConnection `graphql:"secretsVaultsSecrets(sortBy: $sortBy, after: $after, first: $first)"`
+		}
+	}
+	if !slices.Contains(AllVaultSecretsSortEnum, string(sortBy)) {
+		return nil, fmt.Errorf("sortBy must be one of %v. Given: '%s'", AllVaultSecretsSortEnum, sortBy)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["sortBy"] = sortBy
+	if err := client.Query(&q, *variables, WithName("SecretListWithSort")); err != nil {
+		return nil, err
+	}
+	for q.Account.SecretsVaultsSecrets.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.SecretsVaultsSecrets.PageInfo.End
+		resp, err := client.ListSecretsVaultsSecretWithSort(sortBy, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.SecretsVaultsSecrets.Nodes = append(q.Account.SecretsVaultsSecrets.Nodes, resp.Nodes...)
+		q.Account.SecretsVaultsSecrets.PageInfo = resp.PageInfo
+	}
+	q.Account.SecretsVaultsSecrets.TotalCount = len(q.Account.SecretsVaultsSecrets.Nodes)
+	return &q.Account.SecretsVaultsSecrets, nil
+}
+
 func (client *Client) UpdateSecret(identifier string, secretInput SecretInput) (*Secret, error) {
 	var m struct {
 		Payload struct {
@@ -67,6 +67,31 @@ func TestListSecrets(t *testing.T) {
 	autopilot.Equals(t, secretNode[1].Alias, secretNode[1].Owner.Alias)
 }
 
+func TestListSecretsWithSort(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query SecretListWithSort($after:String!$first:Int!$sortBy:VaultSecretsSortEnum!){account{secretsVaultsSecrets(sortBy: $sortBy, after: $after, first: $first){nodes{alias,id,owner{alias,id},timestamps{createdAt,updatedAt}},{{ template "pagination_request" }}}}}`,
+		`{ {{ template "first_page_variables" }}, "sortBy": "updated_at_ASC" }`,
+		`{ "data": { "account": { "secretsVaultsSecrets": { "nodes": [ {{ template "secrets_3" }}, {{ template "secrets_1" }} ], {{ template "no_pagination_response" }} }}}}`,
+	)
+
+	client := BestTestClient(t, "secrets/list_with_sort", testRequest)
+	// Act
+	secretsVaultsSecretConnection, err := client.ListSecretsVaultsSecretWithSort(opslevel.VaultSecretsSortEnumUpdatedAtAsc, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, secretsVaultsSecretConnection.TotalCount)
+}
+
+func TestListSecretsWithSortInvalid(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "secrets/list_with_sort_invalid")
+	// Act
+	_, err := client.ListSecretsVaultsSecretWithSort(opslevel.VaultSecretsSortEnum("created_at_ASC"), nil)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown sort option")
+}
+
 func TestUpdateSecret(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
@@ -1,10 +1,12 @@
 package opslevel
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"slices"
 	"strings"
+	"text/template"
 )
 
 type ServiceId struct {
@@ -16,6 +18,7 @@ type ServiceId struct {
 type Service struct {
 	ApiDocumentPath string `json:"apiDocumentPath,omitempty"`
 	Description     string `json:"description,omitempty"`
+	ExternalUuid    string `json:"externalUuid,omitempty"`
 	Framework       string `json:"framework,omitempty"`
 	HtmlURL         string `json:"htmlUrl"`
 	ServiceId
@@ -24,6 +27,7 @@ type Service struct {
 	Locked                     bool                         `json:"locked" graphql:"locked"`
 	ManagedAliases             []string                     `json:"managedAliases,omitempty"`
 	Name                       string                       `json:"name,omitempty"`
+	Note                       string                       `json:"note,omitempty"`
 	Owner                      TeamId                       `json:"owner,omitempty"`
 	Parent                     *SystemId                    `json:"parent,omitempty" graphql:"parent"`
 	PreferredApiDocument       *ServiceDocument             `json:"preferredApiDocument,omitempty"`
@@ -612,6 +616,42 @@ func (client *Client) ListServicesWithProduct(product string, variables *Payload
 	return &q.Account.Services, nil
 }
 
+func (client *Client) ListServicesWithSort(sortBy ServiceSortEnum, variables *PayloadVariables) (*ServiceConnection, error) {
+	var q struct {
+		Account struct {
+			Services ServiceConnection `graphql:"services(sortBy: $sortBy, after: $after, first: $first)"`
+		}
+	}
+	if !slices.Contains(AllServiceSortEnum, string(sortBy)) {
+		return nil, fmt.Errorf("sortBy must be one of %v. Given: '%s'", AllServiceSortEnum, sortBy)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["sortBy"] = sortBy
+
+	if err := client.Query(&q, *variables, WithName("ServiceListWithSort")); err != nil {
+		return nil, err
+	}
+
+	for q.Account.Services.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Services.PageInfo.End
+		resp, err := client.ListServicesWithSort(sortBy, variables)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range resp.Nodes {
+			if err := node.Hydrate(client); err != nil {
+				return nil, err
+			}
+			q.Account.Services.Nodes = append(q.Account.Services.Nodes, node)
+		}
+		q.Account.Services.PageInfo = resp.PageInfo
+		q.Account.Services.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Services, nil
+}
+
 func NewTagArgs(tag string) (TagArgs, error) {
 	kv := strings.Split(tag, ":")
 	switch len(kv) {
@@ -715,6 +755,197 @@ func (client *Client) UpdateService(input ServiceUpdater) (*Service, error) {
 	return &m.Payload.Service, FormatErrors(m.Payload.Errors)
 }
 
+// ServiceSpec is the desired state of a service used by ReconcileService
+//
+// Nil fields are left unmanaged and a pointer to an empty string unsets the field.
+// Aliases, Tags, Tools, Repositories and Properties are only reconciled when not nil.
+type ServiceSpec struct {
+	Name         *string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Description  *string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner        *string                 `json:"owner,omitempty" yaml:"owner,omitempty"`   // The alias or id of the owning team
+	Parent       *string                 `json:"parent,omitempty" yaml:"parent,omitempty"` // The alias or id of the parent system
+	Tier         *string                 `json:"tier,omitempty" yaml:"tier,omitempty"`     // The tier alias
+	Lifecycle    *string                 `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	Product      *string                 `json:"product,omitempty" yaml:"product,omitempty"`
+	Language     *string                 `json:"language,omitempty" yaml:"language,omitempty"`
+	Framework    *string                 `json:"framework,omitempty" yaml:"framework,omitempty"`
+	Aliases      []string                `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Tags         []Tag                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Tools        []ToolSpec              `json:"tools,omitempty" yaml:"tools,omitempty"`
+	Repositories []ServiceRepositorySpec `json:"repositories,omitempty" yaml:"repositories,omitempty"`
+	Properties   map[string]any          `json:"properties,omitempty" yaml:"properties,omitempty"` // Property definition alias to value
+}
+
+// UpdateInput returns the minimal update that makes 'current' match the spec, or nil when nothing differs
+func (spec ServiceSpec) UpdateInput(current *Service) *ServiceUpdateInputV2 {
+	input := ServiceUpdateInputV2{
+		Id:             &current.Id,
+		Name:           nullableChange(current.Name, spec.Name),
+		Description:    nullableChange(current.Description, spec.Description),
+		TierAlias:      nullableChange(current.Tier.Alias, spec.Tier),
+		LifecycleAlias: nullableChange(current.Lifecycle.Alias, spec.Lifecycle),
+		Product:        nullableChange(current.Product, spec.Product),
+		Language:       nullableChange(current.Language, spec.Language),
+		Framework:      nullableChange(current.Framework, spec.Framework),
+		OwnerInput:     identifierChange(current.Owner.Id, []string{current.Owner.Alias}, spec.Owner),
+	}
+	if current.Parent != nil {
+		input.Parent = identifierChange(current.Parent.Id, current.Parent.Aliases, spec.Parent)
+	} else {
+		input.Parent = identifierChange("", nil, spec.Parent)
+	}
+	if input == (ServiceUpdateInputV2{Id: &current.Id}) {
+		return nil
+	}
+	return &input
+}
+
+// ReconcileService updates the fields of 'current' that differ from 'desired' in a single update and then
+// reconciles its aliases, tags, tools, repositories and properties. Returns the service as it is afterwards,
+// also when some of the changes failed, together with all the errors that occurred.
+func (client *Client) ReconcileService(current *Service, desired ServiceSpec) (*Service, error) {
+	if current.Id == "" {
+		return nil, fmt.Errorf("unable to reconcile service, invalid service id: '%s'", current.Id)
+	}
+	if desired.Name != nil && *desired.Name == "" {
+		return nil, fmt.Errorf("unable to reconcile service '%s', name cannot be empty", current.Id)
+	}
+
+	var allErrors error
+	if input := desired.UpdateInput(current); input != nil {
+		_, err := client.UpdateService(*input)
+		allErrors = errors.Join(allErrors, err)
+	}
+	if desired.Aliases != nil {
+		allErrors = errors.Join(allErrors, current.ReconcileAliases(client, desired.Aliases))
+	}
+	if desired.Tags != nil {
+		allErrors = errors.Join(allErrors, client.ReconcileTags(current, desired.Tags))
+	}
+	if desired.Tools != nil {
+		_, err := client.ReconcileTools(current, desired.Tools)
+		allErrors = errors.Join(allErrors, err)
+	}
+	if desired.Repositories != nil {
+		_, err := client.ReconcileServiceRepositories(current, desired.Repositories)
+		allErrors = errors.Join(allErrors, err)
+	}
+	if desired.Properties != nil {
+		_, err := client.ReconcileProperties(current, desired.Properties)
+		allErrors = errors.Join(allErrors, err)
+	}
+	service, err := client.GetService(current.Id)
+	if err != nil {
+		return nil, errors.Join(allErrors, err)
+	}
+	return service, allErrors
+}
+
+// nullableChange returns the Nullable to set when 'desired' is managed and differs from 'current'
+func nullableChange(current string, desired *string) *Nullable[string] {
+	if desired == nil || *desired == current {
+		return nil
+	}
+	if *desired == "" {
+		return NewNull[string]()
+	}
+	return NewNullableFrom(*desired)
+}
+
+// identifierChange returns the IdentifierInput to set when 'desired' is managed and is neither the id nor an alias of the current resource
+func identifierChange(currentId ID, currentAliases []string, desired *string) *IdentifierInput {
+	if desired == nil {
+		return nil
+	}
+	if *desired == "" {
+		if currentId == "" {
+			return nil
+		}
+		return NewIdentifier() // marshals to null to unset the field
+	}
+	if string(currentId) == *desired || slices.Contains(currentAliases, *desired) {
+		return nil
+	}
+	return NewIdentifier(*desired)
+}
+
+func (client *Client) UpdateServiceNote(input ServiceNoteUpdateInput) (*Service, error) {
+	var m struct {
+		Payload struct {
+			Service Service
+			Errors  []OpsLevelErrors
+		} `graphql:"serviceNoteUpdate(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	if err := client.Mutate(&m, v, WithName("ServiceNoteUpdate")); err != nil {
+		return nil, err
+	}
+	if err := m.Payload.Service.Hydrate(client); err != nil {
+		return &m.Payload.Service, err
+	}
+	return &m.Payload.Service, FormatErrors(m.Payload.Errors)
+}
+
+// AppendServiceNote renders 'tmpl' against the service and appends the output to the service note
+//
+// The output is wrapped in markers named after 'section' so calling this again replaces
+// the previously appended output instead of duplicating it, leaving the rest of the note untouched
+func (client *Client) AppendServiceNote(service *Service, section string, tmpl string) (*Service, error) {
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to append to Note, invalid service id: '%s'", service.Id)
+	}
+	content, err := renderServiceNote(service, tmpl)
+	if err != nil {
+		return nil, err
+	}
+	note, err := upsertNoteSection(service.Note, section, content)
+	if err != nil {
+		return nil, err
+	}
+	if note == service.Note {
+		return service, nil
+	}
+	return client.UpdateServiceNote(ServiceNoteUpdateInput{
+		Service: *NewIdentifier(string(service.Id)),
+		Note:    &note,
+	})
+}
+
+func renderServiceNote(service *Service, tmpl string) (string, error) {
+	t, err := template.New("note").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse note template: %w", err)
+	}
+	var output bytes.Buffer
+	if err := t.Execute(&output, service); err != nil {
+		return "", fmt.Errorf("unable to render note template for service '%s': %w", service.Id, err)
+	}
+	return strings.TrimSpace(output.String()), nil
+}
+
+// returns 'note' with the content between the markers of 'section' replaced, or appended if not yet present
+//
+// A begin marker without its end marker is an error because the end of the section can not be known
+func upsertNoteSection(note, section, content string) (string, error) {
+	begin := fmt.Sprintf("<!-- opslevel-go:begin %s -->", section)
+	end := fmt.Sprintf("<!-- opslevel-go:end %s -->", section)
+	block := fmt.Sprintf("%s\n%s\n%s", begin, content, end)
+
+	if start := strings.Index(note, begin); start >= 0 {
+		stop := strings.Index(note[start:], end)
+		if stop < 0 {
+			return "", fmt.Errorf("note section '%s' has a begin marker without an end marker", section)
+		}
+		return note[:start] + block + note[start+stop+len(end):], nil
+	}
+	if strings.TrimSpace(note) == "" {
+		return block, nil
+	}
+	return strings.TrimRight(note, "\n") + "\n\n" + block, nil
+}
+
 func (client *Client) DeleteService(identifier string) error {
 	input := ServiceDeleteInput{}
 	if IsID(identifier) {
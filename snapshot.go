@@ -0,0 +1,1666 @@
+package opslevel
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const SnapshotVersion = 1
+
+// Snapshot is a portable copy of an account's configuration, made with ExportSnapshot and
+// applied to an account with RestoreSnapshot.
+//
+// Resources reference each other by alias, or by name for resources without aliases, so a
+// snapshot can be restored into a different account where every id is different.
+type Snapshot struct {
+	Version             int                          `json:"version" yaml:"version"`
+	Catalog             CatalogSpec                  `json:"catalog" yaml:"catalog"` // Domains, systems, teams and services, with the tags and tools of services
+	Tags                []SnapshotTags               `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Users               []SnapshotUser               `json:"users,omitempty" yaml:"users,omitempty"`
+	Teams               []SnapshotTeam               `json:"teams,omitempty" yaml:"teams,omitempty"`
+	PropertyDefinitions []SnapshotPropertyDefinition `json:"propertyDefinitions,omitempty" yaml:"propertyDefinitions,omitempty"`
+	Properties          []SnapshotServiceProperties  `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Categories          []SnapshotCategory           `json:"categories,omitempty" yaml:"categories,omitempty"`
+	Levels              []SnapshotLevel              `json:"levels,omitempty" yaml:"levels,omitempty"`
+	Filters             []SnapshotFilter             `json:"filters,omitempty" yaml:"filters,omitempty"`
+	Infrastructure      []SnapshotInfrastructure     `json:"infrastructure,omitempty" yaml:"infrastructure,omitempty"`
+	Scorecards          []SnapshotScorecard          `json:"scorecards,omitempty" yaml:"scorecards,omitempty"`
+	Checks              []SnapshotCheck              `json:"checks,omitempty" yaml:"checks,omitempty"`
+	Skipped             []SnapshotResource           `json:"skipped,omitempty" yaml:"skipped,omitempty"` // Resources ExportSnapshot left out because they can not be restored, not written by WriteSnapshotDir
+}
+
+// SnapshotTags holds the tags of a domain, system or team, the tags of services are part of the catalog
+type SnapshotTags struct {
+	Kind  CatalogResourceKind `json:"kind" yaml:"kind"`
+	Alias string              `json:"alias" yaml:"alias"`
+	Tags  []TagInput          `json:"tags" yaml:"tags"`
+}
+
+type SnapshotUser struct {
+	Email string   `json:"email" yaml:"email"`
+	Name  string   `json:"name" yaml:"name"`
+	Role  UserRole `json:"role,omitempty" yaml:"role,omitempty"`
+}
+
+// SnapshotTeam holds the members and contacts of a team, the team itself is part of the catalog
+type SnapshotTeam struct {
+	Alias    string               `json:"alias" yaml:"alias"`
+	Members  []SnapshotTeamMember `json:"members,omitempty" yaml:"members,omitempty"`
+	Contacts []SnapshotContact    `json:"contacts,omitempty" yaml:"contacts,omitempty"`
+}
+
+type SnapshotTeamMember struct {
+	Email string `json:"email" yaml:"email"`
+	Role  string `json:"role,omitempty" yaml:"role,omitempty"`
+}
+
+type SnapshotContact struct {
+	Type        ContactType `json:"type" yaml:"type"`
+	Address     string      `json:"address" yaml:"address"`
+	DisplayName string      `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+}
+
+type SnapshotPropertyDefinition struct {
+	Alias                 string                    `json:"alias" yaml:"alias"`
+	Name                  string                    `json:"name" yaml:"name"`
+	Description           string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema                map[string]any            `json:"schema" yaml:"schema"`
+	PropertyDisplayStatus PropertyDisplayStatusEnum `json:"propertyDisplayStatus,omitempty" yaml:"propertyDisplayStatus,omitempty"`
+	AllowedInConfigFiles  bool                      `json:"allowedInConfigFiles" yaml:"allowedInConfigFiles"`
+}
+
+// SnapshotServiceProperties holds the property values of a service keyed by property definition alias
+type SnapshotServiceProperties struct {
+	Service string         `json:"service" yaml:"service"` // The alias of the service
+	Values  map[string]any `json:"values" yaml:"values"`
+}
+
+type SnapshotCategory struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type SnapshotLevel struct {
+	Alias       string `json:"alias" yaml:"alias"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Index       int    `json:"index" yaml:"index"`
+}
+
+// SnapshotFilter is a filter whose predicates on ids hold the referenced resource's alias instead,
+// ex: the team alias for `owner_id`, the filter name for `filter_id` and the repository alias for `repository_ids`
+type SnapshotFilter struct {
+	Name       string            `json:"name" yaml:"name"`
+	Connective ConnectiveEnum    `json:"connective,omitempty" yaml:"connective,omitempty"`
+	Predicates []FilterPredicate `json:"predicates,omitempty" yaml:"predicates,omitempty"`
+}
+
+type SnapshotInfrastructure struct {
+	Name     string              `json:"name" yaml:"name"`
+	Schema   string              `json:"schema" yaml:"schema"`
+	Owner    string              `json:"owner,omitempty" yaml:"owner,omitempty"` // The alias of the owning team
+	Provider *InfraProviderInput `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Data     map[string]any      `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+type SnapshotScorecard struct {
+	Alias                       string `json:"alias" yaml:"alias"`
+	Name                        string `json:"name" yaml:"name"`
+	Description                 string `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner                       string `json:"owner" yaml:"owner"`                       // The alias of the owning team
+	Filter                      string `json:"filter,omitempty" yaml:"filter,omitempty"` // The name of the filter
+	AffectsOverallServiceLevels bool   `json:"affectsOverallServiceLevels" yaml:"affectsOverallServiceLevels"`
+}
+
+// SnapshotCheck is a rubric check, or a scorecard check when Scorecard is set, where Config holds
+// the fields of its type's create input, ex: `tagKey` for a tag defined check
+type SnapshotCheck struct {
+	Name        string         `json:"name" yaml:"name"`
+	Type        CheckType      `json:"type" yaml:"type"`
+	Category    string         `json:"category,omitempty" yaml:"category,omitempty"`       // The name of the category of a rubric check
+	Scorecard   string         `json:"scorecard,omitempty" yaml:"scorecard,omitempty"`     // The alias of the scorecard of a scorecard check
+	Level       string         `json:"level" yaml:"level"`                                 // The alias of the level
+	Owner       string         `json:"owner,omitempty" yaml:"owner,omitempty"`             // The alias of the owning team
+	Filter      string         `json:"filter,omitempty" yaml:"filter,omitempty"`           // The name of the filter
+	Integration string         `json:"integration,omitempty" yaml:"integration,omitempty"` // The name of the integration of a custom event check
+	Enabled     bool           `json:"enabled" yaml:"enabled"`
+	Notes       string         `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Config      map[string]any `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+type SnapshotResourceKind string
+
+const (
+	SnapshotResourceKindUser               SnapshotResourceKind = "user"
+	SnapshotResourceKindTeam               SnapshotResourceKind = "team"
+	SnapshotResourceKindPropertyDefinition SnapshotResourceKind = "property_definition"
+	SnapshotResourceKindProperty           SnapshotResourceKind = "property"
+	SnapshotResourceKindCategory           SnapshotResourceKind = "category"
+	SnapshotResourceKindLevel              SnapshotResourceKind = "level"
+	SnapshotResourceKindFilter             SnapshotResourceKind = "filter"
+	SnapshotResourceKindInfrastructure     SnapshotResourceKind = "infrastructure"
+	SnapshotResourceKindScorecard          SnapshotResourceKind = "scorecard"
+	SnapshotResourceKindCheck              SnapshotResourceKind = "check"
+	SnapshotResourceKindTag                SnapshotResourceKind = "tag"
+)
+
+type SnapshotResource struct {
+	Kind SnapshotResourceKind `json:"kind"`
+	Name string               `json:"name"` // The alias, name or email the resource is matched by
+}
+
+// SnapshotRestoreResult lists what RestoreSnapshot changed, in the order it was changed
+type SnapshotRestoreResult struct {
+	Catalog  []CatalogChange    `json:"catalog"`  // Changes to domains, systems, teams and services
+	Created  []SnapshotResource `json:"created"`  // Resources missing from the account
+	Updated  []SnapshotResource `json:"updated"`  // Team members and contacts or service properties that differed
+	Existing []SnapshotResource `json:"existing"` // Resources already in the account, these are left unchanged
+}
+
+type SnapshotRestoreOptions struct {
+	SendWelcomeEmail bool // Email users invited by the restore
+	PruneTeams       bool // Remove team members and contacts that are not in the snapshot
+	PruneProperties  bool // Unassign service property values that are not in the snapshot
+}
+
+// snapshotFiles is the file each section of a Snapshot is written to by WriteSnapshotDir
+var snapshotFiles = []struct {
+	name    string
+	section func(snapshot *Snapshot) any
+}{
+	{"catalog.yaml", func(snapshot *Snapshot) any { return &snapshot.Catalog }},
+	{"tags.yaml", func(snapshot *Snapshot) any { return &snapshot.Tags }},
+	{"users.yaml", func(snapshot *Snapshot) any { return &snapshot.Users }},
+	{"teams.yaml", func(snapshot *Snapshot) any { return &snapshot.Teams }},
+	{"property_definitions.yaml", func(snapshot *Snapshot) any { return &snapshot.PropertyDefinitions }},
+	{"properties.yaml", func(snapshot *Snapshot) any { return &snapshot.Properties }},
+	{"categories.yaml", func(snapshot *Snapshot) any { return &snapshot.Categories }},
+	{"levels.yaml", func(snapshot *Snapshot) any { return &snapshot.Levels }},
+	{"filters.yaml", func(snapshot *Snapshot) any { return &snapshot.Filters }},
+	{"infrastructure.yaml", func(snapshot *Snapshot) any { return &snapshot.Infrastructure }},
+	{"scorecards.yaml", func(snapshot *Snapshot) any { return &snapshot.Scorecards }},
+	{"checks.yaml", func(snapshot *Snapshot) any { return &snapshot.Checks }},
+}
+
+const snapshotManifest = "snapshot.yaml"
+
+// WriteSnapshotDir writes 'snapshot' as a directory of YAML files, one per section plus a
+// `snapshot.yaml` manifest holding the version. Output is stable so snapshots can be diffed.
+func WriteSnapshotDir(dir string, snapshot *Snapshot) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	manifest := struct {
+		Version int `yaml:"version"`
+	}{Version: snapshot.Version}
+	if err := writeSnapshotFile(filepath.Join(dir, snapshotManifest), manifest); err != nil {
+		return err
+	}
+	for _, file := range snapshotFiles {
+		if err := writeSnapshotFile(filepath.Join(dir, file.name), file.section(snapshot)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSnapshotFile(path string, value any) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("unable to write '%s': %w", filepath.Base(path), err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadSnapshotDir reads a directory written by WriteSnapshotDir, files may be YAML or JSON and
+// missing section files are left empty so hand written snapshots only need the sections they use
+func ReadSnapshotDir(dir string) (*Snapshot, error) {
+	manifest := struct {
+		Version int `yaml:"version"`
+	}{}
+	if err := readSnapshotFile(filepath.Join(dir, snapshotManifest), &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Version != SnapshotVersion {
+		return nil, fmt.Errorf("snapshot version must be %d. Given: '%d'", SnapshotVersion, manifest.Version)
+	}
+	output := &Snapshot{Version: manifest.Version}
+	for _, file := range snapshotFiles {
+		path := filepath.Join(dir, file.name)
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := readSnapshotFile(path, file.section(output)); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+func readSnapshotFile(path string, value any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("unable to parse '%s': %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// Exporting
+
+// ExportSnapshot reads the account's configuration into a Snapshot sorted by alias or name.
+// Deactivated users are left out, as are custom and payload checks which are listed in Skipped.
+func (client *Client) ExportSnapshot() (*Snapshot, error) {
+	live, err := client.loadCatalogState()
+	if err != nil {
+		return nil, err
+	}
+	exporter := &snapshotExporter{client: client, live: live}
+	output := &Snapshot{Version: SnapshotVersion, Catalog: exporter.exportCatalog()}
+	steps := []func(snapshot *Snapshot) error{
+		exporter.exportTags,
+		exporter.exportUsers,
+		exporter.exportTeams,
+		exporter.exportPropertyDefinitions,
+		exporter.exportProperties,
+		exporter.exportCategories,
+		exporter.exportLevels,
+		exporter.exportFilters,
+		exporter.exportInfrastructure,
+		exporter.exportScorecards,
+		exporter.exportChecks,
+	}
+	for _, step := range steps {
+		if err := step(output); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+type snapshotExporter struct {
+	client       *Client
+	live         *catalogState
+	filters      []Filter
+	repositories []Repository
+	scorecards   []Scorecard
+}
+
+func (exporter *snapshotExporter) exportCatalog() CatalogSpec {
+	output := CatalogSpec{
+		Version:  1,
+		Domains:  []CatalogDomainSpec{},
+		Systems:  []CatalogSystemSpec{},
+		Teams:    []CatalogTeamSpec{},
+		Services: []CatalogServiceSpec{},
+	}
+	for _, domain := range exporter.live.domains {
+		output.Domains = append(output.Domains, CatalogDomainSpec{
+			Alias:       firstAlias(domain.Aliases, domain.Id),
+			Name:        domain.Name,
+			Description: domain.Description,
+			Owner:       domain.Owner.Alias(),
+			Note:        domain.Note,
+		})
+	}
+	for _, system := range exporter.live.systems {
+		spec := CatalogSystemSpec{
+			Alias:       firstAlias(system.Aliases, system.Id),
+			Name:        system.Name,
+			Description: system.Description,
+			Owner:       system.Owner.Alias(),
+			Note:        system.Note,
+		}
+		if system.Parent.Id != "" {
+			spec.Domain = firstAlias(system.Parent.Aliases, system.Parent.Id)
+		}
+		output.Systems = append(output.Systems, spec)
+	}
+	for _, team := range exporter.live.teams {
+		output.Teams = append(output.Teams, CatalogTeamSpec{
+			Alias:            team.Alias,
+			Name:             team.Name,
+			Responsibilities: team.Responsibilities,
+			Parent:           team.ParentTeam.Alias,
+		})
+	}
+	for _, service := range exporter.live.services {
+		spec := CatalogServiceSpec{
+			Alias:       firstAlias(service.Aliases, service.Id),
+			Name:        service.Name,
+			Description: service.Description,
+			Owner:       service.Owner.Alias,
+			Tier:        service.Tier.Alias,
+			Lifecycle:   service.Lifecycle.Alias,
+			Language:    service.Language,
+			Framework:   service.Framework,
+			Product:     service.Product,
+		}
+		if service.Parent != nil && service.Parent.Id != "" {
+			spec.System = firstAlias(service.Parent.Aliases, service.Parent.Id)
+		}
+		if service.Tags != nil {
+			for _, tag := range service.Tags.Nodes {
+				spec.Tags = append(spec.Tags, TagInput{Key: tag.Key, Value: tag.Value})
+			}
+		}
+		slices.SortFunc(spec.Tags, func(a, b TagInput) int {
+			return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Value, b.Value))
+		})
+		if service.Tools != nil {
+			for _, tool := range service.Tools.Nodes {
+				spec.Tools = append(spec.Tools, ToolSpec{Category: tool.Category, DisplayName: tool.DisplayName, Url: tool.Url, Environment: tool.Environment})
+			}
+		}
+		slices.SortFunc(spec.Tools, func(a, b ToolSpec) int { return cmp.Compare(a.key(), b.key()) })
+		output.Services = append(output.Services, spec)
+	}
+	slices.SortFunc(output.Domains, func(a, b CatalogDomainSpec) int { return cmp.Compare(a.Alias, b.Alias) })
+	slices.SortFunc(output.Systems, func(a, b CatalogSystemSpec) int { return cmp.Compare(a.Alias, b.Alias) })
+	slices.SortFunc(output.Teams, func(a, b CatalogTeamSpec) int { return cmp.Compare(a.Alias, b.Alias) })
+	slices.SortFunc(output.Services, func(a, b CatalogServiceSpec) int { return cmp.Compare(a.Alias, b.Alias) })
+	return output
+}
+
+func (exporter *snapshotExporter) exportTags(snapshot *Snapshot) error {
+	add := func(kind CatalogResourceKind, alias string, tags []Tag) {
+		if len(tags) == 0 {
+			return
+		}
+		output := SnapshotTags{Kind: kind, Alias: alias}
+		for _, tag := range tags {
+			output.Tags = append(output.Tags, TagInput{Key: tag.Key, Value: tag.Value})
+		}
+		slices.SortFunc(output.Tags, func(a, b TagInput) int { return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Value, b.Value)) })
+		snapshot.Tags = append(snapshot.Tags, output)
+	}
+	for _, domain := range exporter.live.domains {
+		tags, err := domain.GetTags(exporter.client, nil)
+		if err != nil {
+			return err
+		}
+		add(CatalogResourceKindDomain, firstAlias(domain.Aliases, domain.Id), tags.Nodes)
+	}
+	for _, system := range exporter.live.systems {
+		tags, err := system.GetTags(exporter.client, nil)
+		if err != nil {
+			return err
+		}
+		add(CatalogResourceKindSystem, firstAlias(system.Aliases, system.Id), tags.Nodes)
+	}
+	for _, team := range exporter.live.teams {
+		if team.Tags == nil || team.Tags.PageInfo.HasNextPage {
+			team.Tags = nil
+			if _, err := team.GetTags(exporter.client, nil); err != nil {
+				return err
+			}
+		}
+		add(CatalogResourceKindTeam, team.Alias, team.Tags.Nodes)
+	}
+	slices.SortFunc(snapshot.Tags, func(a, b SnapshotTags) int {
+		return cmp.Or(cmp.Compare(slices.Index(catalogApplyOrder, a.Kind), slices.Index(catalogApplyOrder, b.Kind)), cmp.Compare(a.Alias, b.Alias))
+	})
+	return nil
+}
+
+func (exporter *snapshotExporter) exportUsers(snapshot *Snapshot) error {
+	users, err := exporter.client.ListUsers(nil)
+	if err != nil {
+		return err
+	}
+	for _, user := range users.Nodes {
+		if user.IsDeactivated() {
+			continue
+		}
+		snapshot.Users = append(snapshot.Users, SnapshotUser{Email: user.Email, Name: user.Name, Role: user.Role})
+	}
+	slices.SortFunc(snapshot.Users, func(a, b SnapshotUser) int { return cmp.Compare(a.Email, b.Email) })
+	return nil
+}
+
+func (exporter *snapshotExporter) exportTeams(snapshot *Snapshot) error {
+	for i := range exporter.live.teams {
+		team := &exporter.live.teams[i]
+		if team.Memberships == nil || team.Memberships.PageInfo.HasNextPage {
+			team.Memberships = nil
+			if _, err := team.GetMemberships(exporter.client, nil); err != nil {
+				return err
+			}
+		}
+		output := SnapshotTeam{Alias: team.Alias}
+		for _, membership := range team.Memberships.Nodes {
+			output.Members = append(output.Members, SnapshotTeamMember{Email: membership.User.Email, Role: membership.Role})
+		}
+		for _, contact := range team.Contacts {
+			output.Contacts = append(output.Contacts, SnapshotContact{Type: contact.Type, Address: contact.Address, DisplayName: contact.DisplayName})
+		}
+		slices.SortFunc(output.Members, func(a, b SnapshotTeamMember) int { return cmp.Compare(a.Email, b.Email) })
+		slices.SortFunc(output.Contacts, func(a, b SnapshotContact) int {
+			return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Address, b.Address))
+		})
+		snapshot.Teams = append(snapshot.Teams, output)
+	}
+	slices.SortFunc(snapshot.Teams, func(a, b SnapshotTeam) int { return cmp.Compare(a.Alias, b.Alias) })
+	return nil
+}
+
+func (exporter *snapshotExporter) exportPropertyDefinitions(snapshot *Snapshot) error {
+	definitions, err := exporter.client.ListPropertyDefinitions(nil)
+	if err != nil {
+		return err
+	}
+	for _, definition := range definitions.Nodes {
+		snapshot.PropertyDefinitions = append(snapshot.PropertyDefinitions, SnapshotPropertyDefinition{
+			Alias:                 firstAlias(definition.Aliases, definition.Id),
+			Name:                  definition.Name,
+			Description:           definition.Description,
+			Schema:                map[string]any(definition.Schema),
+			PropertyDisplayStatus: definition.PropertyDisplayStatus,
+			AllowedInConfigFiles:  definition.AllowedInConfigFiles,
+		})
+	}
+	slices.SortFunc(snapshot.PropertyDefinitions, func(a, b SnapshotPropertyDefinition) int { return cmp.Compare(a.Alias, b.Alias) })
+	return nil
+}
+
+func (exporter *snapshotExporter) exportProperties(snapshot *Snapshot) error {
+	for i := range exporter.live.services {
+		service := &exporter.live.services[i]
+		service.Properties = nil
+		properties, err := service.GetProperties(exporter.client, nil)
+		if err != nil {
+			return err
+		}
+		values := map[string]any{}
+		for _, property := range properties.Nodes {
+			if property.Value == nil {
+				continue
+			}
+			var value any
+			if err := json.Unmarshal([]byte(*property.Value), &value); err != nil {
+				return fmt.Errorf("service '%s' property '%s': %w", firstAlias(service.Aliases, service.Id), firstAlias(property.Definition.Aliases, property.Definition.Id), err)
+			}
+			values[firstAlias(property.Definition.Aliases, property.Definition.Id)] = value
+		}
+		if len(values) > 0 {
+			snapshot.Properties = append(snapshot.Properties, SnapshotServiceProperties{Service: firstAlias(service.Aliases, service.Id), Values: values})
+		}
+	}
+	slices.SortFunc(snapshot.Properties, func(a, b SnapshotServiceProperties) int { return cmp.Compare(a.Service, b.Service) })
+	return nil
+}
+
+func (exporter *snapshotExporter) exportCategories(snapshot *Snapshot) error {
+	categories, err := exporter.client.ListCategories(nil)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories.Nodes {
+		snapshot.Categories = append(snapshot.Categories, SnapshotCategory{Name: category.Name})
+	}
+	slices.SortFunc(snapshot.Categories, func(a, b SnapshotCategory) int { return cmp.Compare(a.Name, b.Name) })
+	return nil
+}
+
+func (exporter *snapshotExporter) exportLevels(snapshot *Snapshot) error {
+	levels, err := exporter.client.ListLevels()
+	if err != nil {
+		return err
+	}
+	for _, level := range levels {
+		snapshot.Levels = append(snapshot.Levels, SnapshotLevel{Alias: level.Alias, Name: level.Name, Description: level.Description, Index: level.Index})
+	}
+	slices.SortFunc(snapshot.Levels, func(a, b SnapshotLevel) int { return cmp.Compare(a.Index, b.Index) })
+	return nil
+}
+
+func (exporter *snapshotExporter) exportFilters(snapshot *Snapshot) error {
+	filters, err := exporter.client.ListFilters(nil)
+	if err != nil {
+		return err
+	}
+	exporter.filters = filters.Nodes
+	for _, filter := range filters.Nodes {
+		output := SnapshotFilter{Name: filter.Name, Connective: filter.Connective}
+		for _, predicate := range filter.Predicates {
+			if predicate.Value != "" {
+				reference, err := exporter.predicateReference(predicate.Key, predicate.Value)
+				if err != nil {
+					return fmt.Errorf("filter '%s': %w", filter.Name, err)
+				}
+				predicate.Value = reference
+			}
+			output.Predicates = append(output.Predicates, predicate)
+		}
+		snapshot.Filters = append(snapshot.Filters, output)
+	}
+	slices.SortFunc(snapshot.Filters, func(a, b SnapshotFilter) int { return cmp.Compare(a.Name, b.Name) })
+	return nil
+}
+
+// predicateReference replaces the id held by filter predicates on resources with an alias or name
+func (exporter *snapshotExporter) predicateReference(key PredicateKeyEnum, value string) (string, error) {
+	var reference string
+	switch key {
+	case PredicateKeyEnumOwnerID, PredicateKeyEnumOwnerIDs:
+		if index := slices.IndexFunc(exporter.live.teams, func(team Team) bool { return string(team.Id) == value }); index >= 0 {
+			reference = exporter.live.teams[index].Alias
+		}
+	case PredicateKeyEnumDomainID:
+		if index := slices.IndexFunc(exporter.live.domains, func(domain Domain) bool { return string(domain.Id) == value }); index >= 0 {
+			reference = firstAlias(exporter.live.domains[index].Aliases, exporter.live.domains[index].Id)
+		}
+	case PredicateKeyEnumSystemID:
+		if index := slices.IndexFunc(exporter.live.systems, func(system System) bool { return string(system.Id) == value }); index >= 0 {
+			reference = firstAlias(exporter.live.systems[index].Aliases, exporter.live.systems[index].Id)
+		}
+	case PredicateKeyEnumFilterID:
+		if index := slices.IndexFunc(exporter.filters, func(filter Filter) bool { return string(filter.Id) == value }); index >= 0 {
+			reference = exporter.filters[index].Name
+		}
+	case PredicateKeyEnumRepositoryIDs:
+		if exporter.repositories == nil {
+			repositories, err := exporter.client.ListRepositories(nil)
+			if err != nil {
+				return "", err
+			}
+			exporter.repositories = repositories.Nodes
+		}
+		if index := slices.IndexFunc(exporter.repositories, func(repository Repository) bool { return string(repository.Id) == value }); index >= 0 {
+			reference = exporter.repositories[index].DefaultAlias
+		}
+	default:
+		return value, nil
+	}
+	if reference == "" {
+		return "", fmt.Errorf("predicate '%s' references unknown id '%s'", key, value)
+	}
+	return reference, nil
+}
+
+func (exporter *snapshotExporter) exportInfrastructure(snapshot *Snapshot) error {
+	resources, err := exporter.client.ListInfrastructure(nil)
+	if err != nil {
+		return err
+	}
+	for _, resource := range resources.Nodes {
+		output := SnapshotInfrastructure{
+			Name:   resource.Name,
+			Schema: resource.Schema,
+			Owner:  resource.Owner.Alias(),
+			Data:   map[string]any(resource.Data),
+		}
+		if resource.ProviderType != "" || resource.ProviderData != (InfrastructureResourceProviderData{}) {
+			output.Provider = &InfraProviderInput{
+				Account: resource.ProviderData.AccountName,
+				Name:    resource.ProviderData.ProviderName,
+				Type:    resource.ProviderType,
+				URL:     resource.ProviderData.ExternalURL,
+			}
+		}
+		snapshot.Infrastructure = append(snapshot.Infrastructure, output)
+	}
+	slices.SortFunc(snapshot.Infrastructure, func(a, b SnapshotInfrastructure) int {
+		return cmp.Or(cmp.Compare(a.Schema, b.Schema), cmp.Compare(a.Name, b.Name))
+	})
+	return nil
+}
+
+func (exporter *snapshotExporter) exportScorecards(snapshot *Snapshot) error {
+	scorecards, err := exporter.client.ListScorecards(nil)
+	if err != nil {
+		return err
+	}
+	exporter.scorecards = scorecards.Nodes
+	for _, scorecard := range scorecards.Nodes {
+		snapshot.Scorecards = append(snapshot.Scorecards, SnapshotScorecard{
+			Alias:                       firstAlias(scorecard.Aliases, scorecard.Id),
+			Name:                        scorecard.Name,
+			Description:                 scorecard.Description,
+			Owner:                       scorecard.Owner.Alias(),
+			Filter:                      scorecard.Filter.Name,
+			AffectsOverallServiceLevels: scorecard.AffectsOverallServiceLevels,
+		})
+	}
+	slices.SortFunc(snapshot.Scorecards, func(a, b SnapshotScorecard) int { return cmp.Compare(a.Alias, b.Alias) })
+	return nil
+}
+
+// exportChecks exports the rubric checks followed by the checks of each scorecard
+func (exporter *snapshotExporter) exportChecks(snapshot *Snapshot) error {
+	checks, err := exporter.client.ListChecks(nil)
+	if err != nil {
+		return err
+	}
+	for _, check := range checks.Nodes {
+		if slices.Contains(snapshotCheckTypesSkipped, check.Type) {
+			snapshot.Skipped = append(snapshot.Skipped, SnapshotResource{Kind: SnapshotResourceKindCheck, Name: check.Name})
+			continue
+		}
+		output, err := snapshotCheck(check)
+		if err != nil {
+			return err
+		}
+		output.Category = check.Category.Name
+		snapshot.Checks = append(snapshot.Checks, *output)
+	}
+	for _, scorecard := range exporter.scorecards {
+		checks, err := scorecard.ListChecks(exporter.client, nil)
+		if err != nil {
+			return err
+		}
+		for _, check := range checks.Nodes {
+			if slices.Contains(snapshotCheckTypesSkipped, check.Type) {
+				snapshot.Skipped = append(snapshot.Skipped, SnapshotResource{Kind: SnapshotResourceKindCheck, Name: check.Name})
+				continue
+			}
+			output, err := snapshotCheck(check)
+			if err != nil {
+				return err
+			}
+			output.Scorecard = firstAlias(scorecard.Aliases, scorecard.Id)
+			snapshot.Checks = append(snapshot.Checks, *output)
+		}
+	}
+	slices.SortFunc(snapshot.Checks, func(a, b SnapshotCheck) int {
+		return cmp.Or(cmp.Compare(a.Scorecard, b.Scorecard), cmp.Compare(a.Name, b.Name))
+	})
+	return nil
+}
+
+// snapshotCheck returns 'check' without its category or scorecard, which the caller sets
+func snapshotCheck(check Check) (*SnapshotCheck, error) {
+	config, err := snapshotCheckConfig(check)
+	if err != nil {
+		return nil, err
+	}
+	output := &SnapshotCheck{
+		Name:    check.Name,
+		Type:    check.Type,
+		Level:   check.Level.Alias,
+		Owner:   check.Owner.Team.Alias,
+		Filter:  check.Filter.Name,
+		Enabled: check.Enabled,
+		Notes:   check.Notes,
+		Config:  config,
+	}
+	if check.Type == CheckTypeGeneric {
+		output.Integration = check.Integration.Name
+	}
+	return output, nil
+}
+
+// snapshotCheckTypesSkipped are check types without a create mutation, exporting them would give checks RestoreSnapshot can not create
+var snapshotCheckTypesSkipped = []CheckType{CheckTypeCustom, CheckTypePayload}
+
+// snapshotCheckCommonFields are create input fields every check has, SnapshotCheck holds these by alias or name
+var snapshotCheckCommonFields = []string{"name", "enabled", "enableOn", "categoryId", "levelId", "ownerId", "filterId", "notes", "integrationId"}
+
+// snapshotCheckConfig returns the fields of the create input for 'check' that are specific to its type
+func snapshotCheckConfig(check Check) (map[string]any, error) {
+	var input any
+	switch check.Type {
+	case CheckTypeGitBranchProtection, CheckTypeHasRepository, CheckTypeHasServiceConfig, CheckTypeServiceDependency:
+		return nil, nil
+	case CheckTypeAlertSourceUsage:
+		input = CheckAlertSourceUsageCreateInput{
+			AlertSourceType:          &check.AlertSourceType,
+			AlertSourceNamePredicate: snapshotPredicate(check.AlertSourceNamePredicate),
+		}
+	case CheckTypeGeneric:
+		input = CheckCustomEventCreateInput{
+			ServiceSelector:  check.ServiceSelector,
+			SuccessCondition: check.SuccessCondition,
+			ResultMessage:    nilIfEmpty(check.ResultMessage),
+			PassPending:      RefOf(check.PassPending),
+		}
+	case CheckTypeHasDocumentation:
+		input = CheckHasDocumentationCreateInput{
+			DocumentType:    check.DocumentType,
+			DocumentSubtype: check.DocumentSubtype,
+		}
+	case CheckTypeHasOwner:
+		ownership := CheckServiceOwnershipCreateInput{
+			RequireContactMethod: check.RequireContactMethod,
+			TagKey:               nilIfEmpty(check.TeamTagKey),
+			TagPredicate:         snapshotPredicate(check.TeamTagPredicate),
+		}
+		if check.ContactMethod != nil {
+			ownership.ContactMethod = RefOf(string(*check.ContactMethod))
+		}
+		input = ownership
+	case CheckTypeHasRecentDeploy:
+		input = CheckHasRecentDeployCreateInput{Days: check.Days}
+	case CheckTypeManual:
+		manual := CheckManualCreateInput{UpdateRequiresComment: check.UpdateRequiresComment}
+		if check.UpdateFrequency != nil {
+			manual.UpdateFrequency = &ManualCheckFrequencyInput{
+				StartingDate:       check.UpdateFrequency.StartingDate,
+				FrequencyTimeScale: check.UpdateFrequency.FrequencyTimeScale,
+				FrequencyValue:     check.UpdateFrequency.FrequencyValue,
+			}
+		}
+		input = manual
+	case CheckTypeRepoFile:
+		input = CheckRepositoryFileCreateInput{
+			DirectorySearch:       RefOf(check.RepositoryFileCheckFragment.DirectorySearch),
+			FilePaths:             check.RepositoryFileCheckFragment.Filepaths,
+			FileContentsPredicate: snapshotPredicate(check.RepositoryFileCheckFragment.FileContentsPredicate),
+			UseAbsoluteRoot:       RefOf(check.UseAbsoluteRoot),
+		}
+	case CheckTypeRepoGrep:
+		input = CheckRepositoryGrepCreateInput{
+			DirectorySearch:       RefOf(check.RepositoryGrepCheckFragment.DirectorySearch),
+			FilePaths:             check.RepositoryGrepCheckFragment.Filepaths,
+			FileContentsPredicate: *snapshotPredicate(&check.RepositoryGrepCheckFragment.FileContentsPredicate),
+		}
+	case CheckTypeRepoSearch:
+		input = CheckRepositorySearchCreateInput{
+			FileExtensions:        &check.FileExtensions,
+			FileContentsPredicate: *snapshotPredicate(&check.RepositorySearchCheckFragment.FileContentsPredicate),
+		}
+	case CheckTypeServiceProperty:
+		property := CheckServicePropertyCreateInput{
+			ServiceProperty:        check.Property,
+			PropertyValuePredicate: snapshotPredicate(check.Predicate),
+		}
+		if check.PropertyDefinition != nil {
+			property.PropertyDefinition = NewIdentifier(firstAlias(check.PropertyDefinition.Aliases, check.PropertyDefinition.Id))
+		}
+		input = property
+	case CheckTypeTagDefined:
+		input = CheckTagDefinedCreateInput{
+			TagKey:       check.TagKey,
+			TagPredicate: snapshotPredicate(check.TagDefinedCheckFragment.TagPredicate),
+		}
+	case CheckTypeToolUsage:
+		input = CheckToolUsageCreateInput{
+			ToolCategory:         check.ToolCategory,
+			ToolNamePredicate:    snapshotPredicate(check.ToolNamePredicate),
+			ToolUrlPredicate:     snapshotPredicate(check.ToolUrlPredicate),
+			EnvironmentPredicate: snapshotPredicate(check.EnvironmentPredicate),
+		}
+	case CheckTypePackageVersion:
+		input = CheckPackageVersionCreateInput{
+			PackageManager:             check.PackageManager,
+			PackageName:                check.PackageName,
+			PackageNameIsRegex:         RefOf(check.PackageNameIsRegex),
+			PackageConstraint:          check.PackageConstraint,
+			MissingPackageResult:       check.MissingPackageResult,
+			VersionConstraintPredicate: snapshotPredicate(check.VersionConstraintPredicate),
+		}
+	default:
+		return nil, fmt.Errorf("check '%s' of type '%s' can not be exported", check.Name, check.Type)
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	output := map[string]any{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, err
+	}
+	for _, field := range snapshotCheckCommonFields {
+		delete(output, field)
+	}
+	return output, nil
+}
+
+func snapshotPredicate(predicate *Predicate) *PredicateInput {
+	if predicate == nil || predicate.Type == "" {
+		return nil
+	}
+	return &PredicateInput{Type: predicate.Type, Value: nilIfEmpty(predicate.Value)}
+}
+
+// Restoring
+
+// RestoreSnapshot recreates 'snapshot' in the account in dependency order: users, the catalog, tags of
+// domains, systems and teams, team members and contacts, categories, levels, property definitions,
+// service properties, filters, infrastructure, scorecards and checks.
+//
+// References are remapped to the account's ids by alias or name. The catalog is applied without
+// pruning. Team members, contacts and service property values in the snapshot are added or updated,
+// the ones missing from it are only removed with PruneTeams and PruneProperties. Every other resource
+// is only created when missing. It stops at the first error and returns what was restored.
+func (client *Client) RestoreSnapshot(snapshot Snapshot, options SnapshotRestoreOptions) (*SnapshotRestoreResult, error) {
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("snapshot version must be %d. Given: '%d'", SnapshotVersion, snapshot.Version)
+	}
+	restorer := &snapshotRestorer{client: client, options: options, result: &SnapshotRestoreResult{}}
+	steps := []func(snapshot Snapshot) error{
+		restorer.restoreUsers,
+		restorer.restoreCatalog,
+		restorer.restoreTags,
+		restorer.restoreTeams,
+		restorer.restoreCategories,
+		restorer.restoreLevels,
+		restorer.restorePropertyDefinitions,
+		restorer.restoreProperties,
+		restorer.restoreFilters,
+		restorer.restoreInfrastructure,
+		restorer.restoreScorecards,
+		restorer.restoreChecks,
+	}
+	for _, step := range steps {
+		if err := step(snapshot); err != nil {
+			return restorer.result, err
+		}
+	}
+	return restorer.result, nil
+}
+
+// snapshotRestorer lists each kind of resource in the account the first time it is referenced
+type snapshotRestorer struct {
+	client  *Client
+	options SnapshotRestoreOptions
+	result  *SnapshotRestoreResult
+
+	teams        []Team
+	domains      []Domain
+	systems      []System
+	categories   []Category
+	levels       []Level
+	filters      []Filter
+	repositories []Repository
+	integrations []Integration
+	scorecards   []Scorecard
+	definitions  []PropertyDefinition
+
+	levelAliases map[string]string // The alias of levels known by another alias in the snapshot, keyed by the snapshot's alias
+}
+
+func (restorer *snapshotRestorer) created(kind SnapshotResourceKind, name string) {
+	restorer.result.Created = append(restorer.result.Created, SnapshotResource{Kind: kind, Name: name})
+}
+
+func (restorer *snapshotRestorer) updated(kind SnapshotResourceKind, name string) {
+	restorer.result.Updated = append(restorer.result.Updated, SnapshotResource{Kind: kind, Name: name})
+}
+
+func (restorer *snapshotRestorer) existing(kind SnapshotResourceKind, name string) {
+	restorer.result.Existing = append(restorer.result.Existing, SnapshotResource{Kind: kind, Name: name})
+}
+
+func (restorer *snapshotRestorer) restoreUsers(snapshot Snapshot) error {
+	if len(snapshot.Users) == 0 {
+		return nil
+	}
+	users, err := restorer.client.ListUsers(nil)
+	if err != nil {
+		return err
+	}
+	for _, user := range snapshot.Users {
+		if slices.ContainsFunc(users.Nodes, func(existing User) bool { return strings.EqualFold(existing.Email, user.Email) }) {
+			restorer.existing(SnapshotResourceKindUser, user.Email)
+			continue
+		}
+		input := UserInput{Name: nilIfEmpty(user.Name), SkipWelcomeEmail: RefOf(!restorer.options.SendWelcomeEmail)}
+		if user.Role != "" {
+			input.Role = RefOf(user.Role)
+		}
+		if _, err := restorer.client.InviteUser(user.Email, input); err != nil {
+			return fmt.Errorf("unable to invite user '%s': %w", user.Email, err)
+		}
+		restorer.created(SnapshotResourceKindUser, user.Email)
+	}
+	return nil
+}
+
+func (restorer *snapshotRestorer) restoreCatalog(snapshot Snapshot) error {
+	spec := snapshot.Catalog
+	if len(spec.Domains)+len(spec.Systems)+len(spec.Teams)+len(spec.Services) == 0 {
+		return nil
+	}
+	_, applied, err := restorer.client.ApplyCatalog(spec, CatalogOptions{})
+	restorer.result.Catalog = applied
+	// Listed again when referenced to pick up what the catalog created
+	restorer.teams, restorer.domains, restorer.systems = nil, nil, nil
+	return err
+}
+
+func (restorer *snapshotRestorer) team(alias string) (*Team, error) {
+	if restorer.teams == nil {
+		teams, err := restorer.client.ListTeams(nil)
+		if err != nil {
+			return nil, err
+		}
+		restorer.teams = append([]Team{}, teams.Nodes...)
+	}
+	for i, team := range restorer.teams {
+		if team.Alias == alias || slices.Contains(team.Aliases, alias) {
+			return &restorer.teams[i], nil
+		}
+	}
+	return nil, fmt.Errorf("team '%s' not found", alias)
+}
+
+func (restorer *snapshotRestorer) teamId(alias string) (*ID, error) {
+	if alias == "" {
+		return nil, nil
+	}
+	team, err := restorer.team(alias)
+	if err != nil {
+		return nil, err
+	}
+	return &team.Id, nil
+}
+
+// domain returns nil when the account has no domain with 'alias'
+func (restorer *snapshotRestorer) domain(alias string) (*Domain, error) {
+	if restorer.domains == nil {
+		domains, err := restorer.client.ListDomains(nil)
+		if err != nil {
+			return nil, err
+		}
+		restorer.domains = domains.Nodes
+	}
+	if index := slices.IndexFunc(restorer.domains, func(domain Domain) bool { return slices.Contains(domain.Aliases, alias) }); index >= 0 {
+		return &restorer.domains[index], nil
+	}
+	return nil, nil
+}
+
+// system returns nil when the account has no system with 'alias'
+func (restorer *snapshotRestorer) system(alias string) (*System, error) {
+	if restorer.systems == nil {
+		systems, err := restorer.client.ListSystems(nil)
+		if err != nil {
+			return nil, err
+		}
+		restorer.systems = systems.Nodes
+	}
+	if index := slices.IndexFunc(restorer.systems, func(system System) bool { return slices.Contains(system.Aliases, alias) }); index >= 0 {
+		return &restorer.systems[index], nil
+	}
+	return nil, nil
+}
+
+// restoreTags assigns the tags of domains, systems and teams missing from the account, other tags are left as they are
+func (restorer *snapshotRestorer) restoreTags(snapshot Snapshot) error {
+	for _, spec := range snapshot.Tags {
+		var id ID
+		var current *TagConnection
+		var tagsErr error
+		switch spec.Kind {
+		case CatalogResourceKindDomain:
+			domain, err := restorer.domain(spec.Alias)
+			if err != nil {
+				return err
+			}
+			if domain == nil {
+				return fmt.Errorf("domain '%s' not found", spec.Alias)
+			}
+			id = domain.Id
+			current, tagsErr = domain.GetTags(restorer.client, nil)
+		case CatalogResourceKindSystem:
+			system, err := restorer.system(spec.Alias)
+			if err != nil {
+				return err
+			}
+			if system == nil {
+				return fmt.Errorf("system '%s' not found", spec.Alias)
+			}
+			id = system.Id
+			current, tagsErr = system.GetTags(restorer.client, nil)
+		case CatalogResourceKindTeam:
+			team, err := restorer.team(spec.Alias)
+			if err != nil {
+				return err
+			}
+			id = team.Id
+			if team.Tags == nil || team.Tags.PageInfo.HasNextPage {
+				team.Tags = nil
+				current, tagsErr = team.GetTags(restorer.client, nil)
+			} else {
+				current = team.Tags
+			}
+		default:
+			return fmt.Errorf("tags of %s '%s' can not be restored", spec.Kind, spec.Alias)
+		}
+		if tagsErr != nil {
+			return fmt.Errorf("unable to restore tags of %s '%s': %w", spec.Kind, spec.Alias, tagsErr)
+		}
+		missing := slices.DeleteFunc(slices.Clone(spec.Tags), func(tag TagInput) bool {
+			return slices.ContainsFunc(current.Nodes, func(existing Tag) bool { return existing.Key == tag.Key && existing.Value == tag.Value })
+		})
+		if len(missing) == 0 {
+			continue
+		}
+		if _, err := restorer.client.AssignTagsWithTagInputs(string(id), missing); err != nil {
+			return fmt.Errorf("unable to restore tags of %s '%s': %w", spec.Kind, spec.Alias, err)
+		}
+		for _, tag := range missing {
+			restorer.created(SnapshotResourceKindTag, fmt.Sprintf("%s %s:%s", spec.Alias, tag.Key, tag.Value))
+		}
+	}
+	return nil
+}
+
+func (restorer *snapshotRestorer) restoreTeams(snapshot Snapshot) error {
+	for _, spec := range snapshot.Teams {
+		team, err := restorer.team(spec.Alias)
+		if err != nil {
+			return err
+		}
+		membershipsWanted := []TeamMembershipUserInput{}
+		for _, member := range spec.Members {
+			membershipsWanted = append(membershipsWanted, TeamMembershipUserInput{User: NewUserIdentifier(member.Email), Role: nilIfEmpty(member.Role)})
+		}
+		contactsWanted := []ContactInput{}
+		for _, contact := range spec.Contacts {
+			contactsWanted = append(contactsWanted, ContactInput{Type: contact.Type, Address: contact.Address, DisplayName: nilIfEmpty(contact.DisplayName)})
+		}
+		if !restorer.options.PruneTeams {
+			// Members and contacts missing from the snapshot are wanted as they are so reconciling leaves them alone
+			if team.Memberships == nil || team.Memberships.PageInfo.HasNextPage {
+				team.Memberships = nil
+				if _, err := team.GetMemberships(restorer.client, nil); err != nil {
+					return fmt.Errorf("unable to restore members of team '%s': %w", spec.Alias, err)
+				}
+			}
+			for _, membership := range team.Memberships.Nodes {
+				if !slices.ContainsFunc(membershipsWanted, func(wanted TeamMembershipUserInput) bool { return membership.User.matches(wanted.User) }) {
+					membershipsWanted = append(membershipsWanted, TeamMembershipUserInput{User: membership.input().User})
+				}
+			}
+			for _, contact := range team.Contacts {
+				if !slices.ContainsFunc(contactsWanted, contact.matches) {
+					contactsWanted = append(contactsWanted, ContactInput{Type: contact.Type, Address: contact.Address})
+				}
+			}
+		}
+		memberships, err := team.ReconcileMemberships(restorer.client, membershipsWanted)
+		if err != nil {
+			return fmt.Errorf("unable to restore members of team '%s': %w", spec.Alias, err)
+		}
+		contacts, err := team.ReconcileContacts(restorer.client, contactsWanted)
+		if err != nil {
+			return fmt.Errorf("unable to restore contacts of team '%s': %w", spec.Alias, err)
+		}
+		if !memberships.IsEmpty() || len(contacts.Added)+len(contacts.Updated)+len(contacts.Removed) > 0 {
+			restorer.updated(SnapshotResourceKindTeam, spec.Alias)
+		}
+	}
+	return nil
+}
+
+// category returns nil when the account has no category named 'name'
+func (restorer *snapshotRestorer) category(name string) (*Category, error) {
+	if restorer.categories == nil {
+		categories, err := restorer.client.ListCategories(nil)
+		if err != nil {
+			return nil, err
+		}
+		restorer.categories = append([]Category{}, categories.Nodes...)
+	}
+	if index := slices.IndexFunc(restorer.categories, func(category Category) bool { return category.Name == name }); index >= 0 {
+		return &restorer.categories[index], nil
+	}
+	return nil, nil
+}
+
+func (restorer *snapshotRestorer) restoreCategories(snapshot Snapshot) error {
+	for _, spec := range snapshot.Categories {
+		if existing, err := restorer.category(spec.Name); err != nil {
+			return err
+		} else if existing != nil {
+			restorer.existing(SnapshotResourceKindCategory, spec.Name)
+			continue
+		}
+		category, err := restorer.client.CreateCategory(CategoryCreateInput{Name: spec.Name})
+		if err != nil {
+			return fmt.Errorf("unable to create category '%s': %w", spec.Name, err)
+		}
+		restorer.categories = append(restorer.categories, *category)
+		restorer.created(SnapshotResourceKindCategory, spec.Name)
+	}
+	return nil
+}
+
+// level returns nil when the account has no level with 'alias'
+func (restorer *snapshotRestorer) level(alias string) (*Level, error) {
+	if restorer.levels == nil {
+		levels, err := restorer.client.ListLevels()
+		if err != nil {
+			return nil, err
+		}
+		restorer.levels = append([]Level{}, levels...)
+	}
+	if actual, ok := restorer.levelAliases[alias]; ok {
+		alias = actual
+	}
+	if index := slices.IndexFunc(restorer.levels, func(level Level) bool { return level.Alias == alias }); index >= 0 {
+		return &restorer.levels[index], nil
+	}
+	return nil, nil
+}
+
+// aliasLevel makes 'level' known by the snapshot's 'alias' when the account gave it another one
+func (restorer *snapshotRestorer) aliasLevel(alias string, level Level) {
+	if level.Alias == alias {
+		return
+	}
+	if restorer.levelAliases == nil {
+		restorer.levelAliases = map[string]string{}
+	}
+	restorer.levelAliases[alias] = level.Alias
+}
+
+// restoreLevels matches levels by alias then by name. Missing levels are created in index order
+// and a level whose index is taken in the account is placed above every level instead.
+func (restorer *snapshotRestorer) restoreLevels(snapshot Snapshot) error {
+	specs := slices.Clone(snapshot.Levels)
+	slices.SortStableFunc(specs, func(a, b SnapshotLevel) int { return cmp.Compare(a.Index, b.Index) })
+	for _, spec := range specs {
+		if existing, err := restorer.level(spec.Alias); err != nil {
+			return err
+		} else if existing != nil {
+			restorer.existing(SnapshotResourceKindLevel, spec.Alias)
+			continue
+		}
+		if index := slices.IndexFunc(restorer.levels, func(level Level) bool { return level.Name == spec.Name }); index >= 0 {
+			restorer.aliasLevel(spec.Alias, restorer.levels[index])
+			restorer.existing(SnapshotResourceKindLevel, spec.Alias)
+			continue
+		}
+		input := LevelCreateInput{Name: spec.Name, Description: nilIfEmpty(spec.Description), Index: RefOf(spec.Index)}
+		if slices.ContainsFunc(restorer.levels, func(level Level) bool { return level.Index == spec.Index }) {
+			top := slices.MaxFunc(restorer.levels, func(a, b Level) int { return cmp.Compare(a.Index, b.Index) })
+			input.Index = RefOf(top.Index + 1)
+		}
+		level, err := restorer.client.CreateLevel(input)
+		if err != nil {
+			return fmt.Errorf("unable to create level '%s': %w", spec.Alias, err)
+		}
+		restorer.levels = append(restorer.levels, *level)
+		restorer.aliasLevel(spec.Alias, *level)
+		restorer.created(SnapshotResourceKindLevel, spec.Alias)
+	}
+	return nil
+}
+
+// propertyDefinition returns nil when the account has no property definition with 'alias'
+func (restorer *snapshotRestorer) propertyDefinition(alias string) (*PropertyDefinition, error) {
+	if restorer.definitions == nil {
+		definitions, err := restorer.client.ListPropertyDefinitions(nil)
+		if err != nil {
+			return nil, err
+		}
+		restorer.definitions = append([]PropertyDefinition{}, definitions.Nodes...)
+	}
+	if index := slices.IndexFunc(restorer.definitions, func(definition PropertyDefinition) bool { return slices.Contains(definition.Aliases, alias) }); index >= 0 {
+		return &restorer.definitions[index], nil
+	}
+	return nil, nil
+}
+
+// restorePropertyDefinitions matches property definitions by alias then by name, the snapshot's alias
+// is recorded against a definition matched by name or created with another alias
+func (restorer *snapshotRestorer) restorePropertyDefinitions(snapshot Snapshot) error {
+	for _, spec := range snapshot.PropertyDefinitions {
+		if existing, err := restorer.propertyDefinition(spec.Alias); err != nil {
+			return err
+		} else if existing != nil {
+			restorer.existing(SnapshotResourceKindPropertyDefinition, spec.Alias)
+			continue
+		}
+		if index := slices.IndexFunc(restorer.definitions, func(definition PropertyDefinition) bool { return definition.Name == spec.Name }); index >= 0 {
+			restorer.definitions[index].Aliases = append(restorer.definitions[index].Aliases, spec.Alias)
+			restorer.existing(SnapshotResourceKindPropertyDefinition, spec.Alias)
+			continue
+		}
+		definition, err := restorer.client.CreatePropertyDefinition(PropertyDefinitionInput{
+			Name:                  RefOf(spec.Name),
+			Description:           nilIfEmpty(spec.Description),
+			Schema:                RefOf(JSONSchema(spec.Schema)),
+			PropertyDisplayStatus: nilIfEmptyDisplayStatus(spec.PropertyDisplayStatus),
+			AllowedInConfigFiles:  RefOf(spec.AllowedInConfigFiles),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to create property definition '%s': %w", spec.Alias, err)
+		}
+		if !slices.Contains(definition.Aliases, spec.Alias) {
+			definition.Aliases = append(definition.Aliases, spec.Alias)
+		}
+		restorer.definitions = append(restorer.definitions, *definition)
+		restorer.created(SnapshotResourceKindPropertyDefinition, spec.Alias)
+	}
+	return nil
+}
+
+func nilIfEmptyDisplayStatus(value PropertyDisplayStatusEnum) *PropertyDisplayStatusEnum {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func (restorer *snapshotRestorer) restoreProperties(snapshot Snapshot) error {
+	for _, spec := range snapshot.Properties {
+		serviceId, err := restorer.client.GetServiceIdWithAlias(spec.Service)
+		if err != nil {
+			return err
+		}
+		if serviceId.Id == "" {
+			return fmt.Errorf("service '%s' not found", spec.Service)
+		}
+		// Values are keyed by definition id since a definition may be known by another alias in the account
+		values := map[string]any{}
+		for alias, value := range spec.Values {
+			definition, err := restorer.propertyDefinition(alias)
+			if err != nil {
+				return err
+			}
+			if definition == nil {
+				return fmt.Errorf("service '%s': property definition '%s' not found", spec.Service, alias)
+			}
+			values[string(definition.Id)] = value
+		}
+		service := &Service{ServiceId: *serviceId}
+		if !restorer.options.PruneProperties {
+			// Values missing from the snapshot are wanted as they are so reconciling leaves them assigned
+			current, err := service.GetProperties(restorer.client, nil)
+			if err != nil {
+				return fmt.Errorf("unable to restore properties of service '%s': %w", spec.Service, err)
+			}
+			for _, property := range current.Nodes {
+				if property.Value == nil || slices.ContainsFunc(mapKeys(values), property.Definition.matches) {
+					continue
+				}
+				value, err := JsonStringAs[any](*property.Value)
+				if err != nil {
+					return fmt.Errorf("unable to restore properties of service '%s': %w", spec.Service, err)
+				}
+				values[string(property.Definition.Id)] = value
+			}
+		}
+		result, err := restorer.client.ReconcileProperties(service, values)
+		if err != nil {
+			return fmt.Errorf("unable to restore properties of service '%s': %w", spec.Service, err)
+		}
+		if len(result.Assigned)+len(result.Unassigned) > 0 {
+			restorer.updated(SnapshotResourceKindProperty, spec.Service)
+		}
+	}
+	return nil
+}
+
+// filter returns nil when the account has no filter named 'name'
+func (restorer *snapshotRestorer) filter(name string) (*Filter, error) {
+	if restorer.filters == nil {
+		filters, err := restorer.client.ListFilters(nil)
+		if err != nil {
+			return nil, err
+		}
+		restorer.filters = append([]Filter{}, filters.Nodes...)
+	}
+	if index := slices.IndexFunc(restorer.filters, func(filter Filter) bool { return filter.Name == name }); index >= 0 {
+		return &restorer.filters[index], nil
+	}
+	return nil, nil
+}
+
+func (restorer *snapshotRestorer) filterId(name string) (*ID, error) {
+	if name == "" {
+		return nil, nil
+	}
+	filter, err := restorer.filter(name)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		return nil, fmt.Errorf("filter '%s' not found", name)
+	}
+	return &filter.Id, nil
+}
+
+// filters are created once every filter they reference by `filter_id` exists
+func (restorer *snapshotRestorer) restoreFilters(snapshot Snapshot) error {
+	pending := slices.Clone(snapshot.Filters)
+	for len(pending) > 0 {
+		var waiting []SnapshotFilter
+		for _, spec := range pending {
+			if existing, err := restorer.filter(spec.Name); err != nil {
+				return err
+			} else if existing != nil {
+				restorer.existing(SnapshotResourceKindFilter, spec.Name)
+				continue
+			}
+			if slices.ContainsFunc(spec.Predicates, func(predicate FilterPredicate) bool {
+				return predicate.Key == PredicateKeyEnumFilterID && slices.ContainsFunc(pending, func(other SnapshotFilter) bool { return other.Name == predicate.Value })
+			}) {
+				waiting = append(waiting, spec)
+				continue
+			}
+			predicates := []FilterPredicateInput{}
+			for _, predicate := range spec.Predicates {
+				value, err := restorer.predicateValue(predicate.Key, predicate.Value)
+				if err != nil {
+					return fmt.Errorf("filter '%s': %w", spec.Name, err)
+				}
+				predicates = append(predicates, FilterPredicateInput{
+					Type:          predicate.Type,
+					Value:         nilIfEmpty(value),
+					Key:           predicate.Key,
+					KeyData:       nilIfEmpty(predicate.KeyData),
+					CaseSensitive: predicate.CaseSensitive,
+				})
+			}
+			input := FilterCreateInput{Name: spec.Name, Predicates: &predicates}
+			if spec.Connective != "" {
+				input.Connective = RefOf(spec.Connective)
+			}
+			filter, err := restorer.client.CreateFilter(input)
+			if err != nil {
+				return fmt.Errorf("unable to create filter '%s': %w", spec.Name, err)
+			}
+			restorer.filters = append(restorer.filters, *filter)
+			restorer.created(SnapshotResourceKindFilter, spec.Name)
+		}
+		if len(waiting) == len(pending) {
+			return fmt.Errorf("filters reference each other in a cycle: '%s'", waiting[0].Name)
+		}
+		pending = waiting
+	}
+	return nil
+}
+
+// predicateValue replaces the alias or name held by filter predicates on resources with the account's id
+func (restorer *snapshotRestorer) predicateValue(key PredicateKeyEnum, reference string) (string, error) {
+	if reference == "" {
+		return "", nil
+	}
+	switch key {
+	case PredicateKeyEnumOwnerID, PredicateKeyEnumOwnerIDs:
+		id, err := restorer.teamId(reference)
+		if err != nil {
+			return "", err
+		}
+		return string(*id), nil
+	case PredicateKeyEnumDomainID:
+		domain, err := restorer.domain(reference)
+		if err != nil {
+			return "", err
+		}
+		if domain == nil {
+			return "", fmt.Errorf("domain '%s' not found", reference)
+		}
+		return string(domain.Id), nil
+	case PredicateKeyEnumSystemID:
+		system, err := restorer.system(reference)
+		if err != nil {
+			return "", err
+		}
+		if system == nil {
+			return "", fmt.Errorf("system '%s' not found", reference)
+		}
+		return string(system.Id), nil
+	case PredicateKeyEnumFilterID:
+		id, err := restorer.filterId(reference)
+		if err != nil {
+			return "", err
+		}
+		return string(*id), nil
+	case PredicateKeyEnumRepositoryIDs:
+		if restorer.repositories == nil {
+			repositories, err := restorer.client.ListRepositories(nil)
+			if err != nil {
+				return "", err
+			}
+			restorer.repositories = repositories.Nodes
+		}
+		if index := slices.IndexFunc(restorer.repositories, func(repository Repository) bool { return repository.DefaultAlias == reference }); index >= 0 {
+			return string(restorer.repositories[index].Id), nil
+		}
+		return "", fmt.Errorf("repository '%s' not found", reference)
+	}
+	return reference, nil
+}
+
+func (restorer *snapshotRestorer) restoreInfrastructure(snapshot Snapshot) error {
+	if len(snapshot.Infrastructure) == 0 {
+		return nil
+	}
+	resources, err := restorer.client.ListInfrastructure(nil)
+	if err != nil {
+		return err
+	}
+	for _, spec := range snapshot.Infrastructure {
+		if slices.ContainsFunc(resources.Nodes, func(resource InfrastructureResource) bool {
+			return resource.Schema == spec.Schema && resource.Name == spec.Name
+		}) {
+			restorer.existing(SnapshotResourceKindInfrastructure, spec.Name)
+			continue
+		}
+		owner, err := restorer.teamId(spec.Owner)
+		if err != nil {
+			return fmt.Errorf("infrastructure '%s': %w", spec.Name, err)
+		}
+		if _, err := restorer.client.CreateInfrastructure(InfraInput{
+			Schema:   spec.Schema,
+			Owner:    owner,
+			Provider: spec.Provider,
+			Data:     RefOf(JSON(spec.Data)),
+		}); err != nil {
+			return fmt.Errorf("unable to create infrastructure '%s': %w", spec.Name, err)
+		}
+		restorer.created(SnapshotResourceKindInfrastructure, spec.Name)
+	}
+	return nil
+}
+
+func (restorer *snapshotRestorer) restoreScorecards(snapshot Snapshot) error {
+	if len(snapshot.Scorecards) == 0 {
+		return nil
+	}
+	for _, spec := range snapshot.Scorecards {
+		if existing, err := restorer.scorecard(spec.Alias); err != nil {
+			return err
+		} else if existing != nil {
+			restorer.existing(SnapshotResourceKindScorecard, spec.Alias)
+			continue
+		}
+		// A scorecard with the same name is the same scorecard, known by the snapshot's alias from here on
+		if index := slices.IndexFunc(restorer.scorecards, func(scorecard Scorecard) bool { return scorecard.Name == spec.Name }); index >= 0 {
+			restorer.scorecards[index].Aliases = append(restorer.scorecards[index].Aliases, spec.Alias)
+			restorer.existing(SnapshotResourceKindScorecard, spec.Alias)
+			continue
+		}
+		owner, err := restorer.teamId(spec.Owner)
+		if err != nil {
+			return fmt.Errorf("scorecard '%s': %w", spec.Alias, err)
+		}
+		if owner == nil {
+			return fmt.Errorf("scorecard '%s' must have an owner", spec.Alias)
+		}
+		filter, err := restorer.filterId(spec.Filter)
+		if err != nil {
+			return fmt.Errorf("scorecard '%s': %w", spec.Alias, err)
+		}
+		scorecard, err := restorer.client.CreateScorecard(ScorecardInput{
+			Name:                        spec.Name,
+			Description:                 nilIfEmpty(spec.Description),
+			OwnerId:                     *owner,
+			FilterId:                    filter,
+			AffectsOverallServiceLevels: RefOf(spec.AffectsOverallServiceLevels),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to create scorecard '%s': %w", spec.Alias, err)
+		}
+		// The alias generated for the new scorecard may differ from the one in the snapshot
+		if !slices.Contains(scorecard.Aliases, spec.Alias) {
+			scorecard.Aliases = append(scorecard.Aliases, spec.Alias)
+		}
+		restorer.scorecards = append(restorer.scorecards, *scorecard)
+		restorer.created(SnapshotResourceKindScorecard, spec.Alias)
+	}
+	return nil
+}
+
+// scorecard returns nil when the account has no scorecard with 'alias'
+func (restorer *snapshotRestorer) scorecard(alias string) (*Scorecard, error) {
+	if restorer.scorecards == nil {
+		scorecards, err := restorer.client.ListScorecards(nil)
+		if err != nil {
+			return nil, err
+		}
+		restorer.scorecards = append([]Scorecard{}, scorecards.Nodes...)
+	}
+	if index := slices.IndexFunc(restorer.scorecards, func(scorecard Scorecard) bool { return slices.Contains(scorecard.Aliases, alias) }); index >= 0 {
+		return &restorer.scorecards[index], nil
+	}
+	return nil, nil
+}
+
+func (restorer *snapshotRestorer) integrationId(name string) (ID, error) {
+	if restorer.integrations == nil {
+		integrations, err := restorer.client.ListIntegrations(nil)
+		if err != nil {
+			return "", err
+		}
+		restorer.integrations = integrations.Nodes
+	}
+	if index := slices.IndexFunc(restorer.integrations, func(integration Integration) bool { return integration.Name == name }); index >= 0 {
+		return restorer.integrations[index].Id, nil
+	}
+	return "", fmt.Errorf("integration '%s' not found", name)
+}
+
+func (restorer *snapshotRestorer) restoreChecks(snapshot Snapshot) error {
+	if len(snapshot.Checks) == 0 {
+		return nil
+	}
+	// Checks are matched by name within the rubric or their scorecard, keyed by scorecard alias
+	checks := map[string][]Check{}
+	for _, spec := range snapshot.Checks {
+		if _, ok := checks[spec.Scorecard]; !ok {
+			existing, err := restorer.checks(spec.Scorecard)
+			if err != nil {
+				return fmt.Errorf("check '%s': %w", spec.Name, err)
+			}
+			checks[spec.Scorecard] = existing
+		}
+		if slices.ContainsFunc(checks[spec.Scorecard], func(check Check) bool { return check.Name == spec.Name }) {
+			restorer.existing(SnapshotResourceKindCheck, spec.Name)
+			continue
+		}
+		input, err := restorer.checkInput(spec)
+		if err != nil {
+			return fmt.Errorf("check '%s': %w", spec.Name, err)
+		}
+		if _, err := restorer.client.CreateCheck(input); err != nil {
+			return fmt.Errorf("unable to create check '%s': %w", spec.Name, err)
+		}
+		restorer.created(SnapshotResourceKindCheck, spec.Name)
+	}
+	return nil
+}
+
+// checks lists the rubric checks, or the checks of the scorecard with 'scorecardAlias' when set
+func (restorer *snapshotRestorer) checks(scorecardAlias string) ([]Check, error) {
+	if scorecardAlias == "" {
+		checks, err := restorer.client.ListChecks(nil)
+		if err != nil {
+			return nil, err
+		}
+		return checks.Nodes, nil
+	}
+	scorecard, err := restorer.scorecard(scorecardAlias)
+	if err != nil {
+		return nil, err
+	}
+	if scorecard == nil {
+		return nil, fmt.Errorf("scorecard '%s' not found", scorecardAlias)
+	}
+	checks, err := scorecard.ListChecks(restorer.client, nil)
+	if err != nil {
+		return nil, err
+	}
+	return checks.Nodes, nil
+}
+
+func (restorer *snapshotRestorer) checkInput(spec SnapshotCheck) (any, error) {
+	if _, ok := CheckCreateConstructors[spec.Type]; !ok {
+		return nil, fmt.Errorf("unknown check type '%s'", spec.Type)
+	}
+	fields := map[string]any{}
+	maps.Copy(fields, spec.Config)
+	fields["name"] = spec.Name
+	fields["enabled"] = spec.Enabled
+	if spec.Notes != "" {
+		fields["notes"] = spec.Notes
+	}
+	// A scorecard check is created with its scorecard's id in place of a category id
+	if spec.Scorecard != "" {
+		scorecard, err := restorer.scorecard(spec.Scorecard)
+		if err != nil {
+			return nil, err
+		}
+		if scorecard == nil {
+			return nil, fmt.Errorf("scorecard '%s' not found", spec.Scorecard)
+		}
+		fields["categoryId"] = scorecard.Id
+	} else {
+		category, err := restorer.category(spec.Category)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			return nil, fmt.Errorf("category '%s' not found", spec.Category)
+		}
+		fields["categoryId"] = category.Id
+	}
+	level, err := restorer.level(spec.Level)
+	if err != nil {
+		return nil, err
+	}
+	if level == nil {
+		return nil, fmt.Errorf("level '%s' not found", spec.Level)
+	}
+	fields["levelId"] = level.Id
+	if owner, err := restorer.teamId(spec.Owner); err != nil {
+		return nil, err
+	} else if owner != nil {
+		fields["ownerId"] = *owner
+	}
+	if filter, err := restorer.filterId(spec.Filter); err != nil {
+		return nil, err
+	} else if filter != nil {
+		fields["filterId"] = *filter
+	}
+	if spec.Integration != "" {
+		integration, err := restorer.integrationId(spec.Integration)
+		if err != nil {
+			return nil, err
+		}
+		fields["integrationId"] = integration
+	}
+	if definition, ok := fields["propertyDefinition"].(map[string]any); ok {
+		if alias, ok := definition["alias"].(string); ok {
+			existing, err := restorer.propertyDefinition(alias)
+			if err != nil {
+				return nil, err
+			}
+			if existing == nil {
+				return nil, fmt.Errorf("property definition '%s' not found", alias)
+			}
+			fields["propertyDefinition"] = map[string]any{"id": existing.Id}
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalCheckCreateInput(spec.Type, data)
+}
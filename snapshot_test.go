@@ -0,0 +1,723 @@
+package opslevel_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2024"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+// exportSnapshotRequests are the requests ExportSnapshot makes for an account with one of each resource
+func exportSnapshotRequests() []autopilot.TestRequest {
+	testRequestOne := autopilot.NewTestRequest(
+		`{{ template "catalog_domain_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "domains": { "nodes": [ { "id": "Z2lkOi8vMTMwMQ", "aliases": ["commerce"], "name": "Commerce" } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`{{ template "catalog_system_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "systems": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [ { "alias": "platform", {{ template "id2" }}, "aliases": ["platform"], "name": "Platform",
+			"contacts": [ { "type": "slack", "address": "#platform", "displayName": "Platform" } ],
+			"memberships": { "nodes": [
+				{ "role": "admin", "team": { "alias": "platform", {{ template "id2" }} }, "user": { "id": "Z2lkOi8vNTAy", "email": "b@example.com" } },
+				{ "role": "", "team": { "alias": "platform", {{ template "id2" }} }, "user": { "id": "Z2lkOi8vNTAx", "email": "a@example.com" } }
+			] },
+			"tags": { "nodes": [ { "id": "Z2lkOi8vMTMwMw", "key": "tier", "value": "1" }, { "id": "Z2lkOi8vMTMwMg", "key": "area", "value": "core" } ], {{ template "no_pagination_response" }} } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`{{ template "catalog_service_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "services": { "nodes": [
+			{ {{ template "id3" }}, "aliases": ["checkout"], "name": "Checkout", "owner": { "alias": "platform", {{ template "id2" }} }, "tier": { "alias": "tier_1" },
+			  "tags": { "nodes": [ { {{ template "id4" }}, "key": "env", "value": "prod" } ] } }
+		], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestDomainTags := autopilot.NewTestRequest(
+		`query DomainTagsList($after:String!$domain:IdentifierInput!$first:Int!){account{domain(input: $domain){tags(after: $after, first: $first){nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "domain": { "id": "Z2lkOi8vMTMwMQ" } }`,
+		`{ "data": { "account": { "domain": { "tags": { "nodes": [ { "id": "Z2lkOi8vMTMwNA", "key": "owner", "value": "commerce" } ], {{ template "no_pagination_response" }} }}}}}`,
+	)
+	testRequestFive := autopilot.NewTestRequest(
+		`query UserList($after:String!$first:Int!){account{users(after: $after, first: $first){nodes{id,email,deactivatedAt,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "users": { "nodes": [
+			{ "id": "Z2lkOi8vNTAy", "email": "b@example.com", "name": "B", "role": "user" },
+			{ "id": "Z2lkOi8vNTAx", "email": "a@example.com", "name": "A", "role": "admin" },
+			{ "id": "Z2lkOi8vNTAz", "email": "gone@example.com", "name": "Gone", "role": "user", "deactivatedAt": "2024-01-01T00:00:00Z" }
+		], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestSix := autopilot.NewTestRequest(
+		`query PropertyDefinitionList($after:String!$first:Int!){account{propertyDefinitions(after: $after, first: $first){nodes{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},{{ template "pagination_request" }}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "propertyDefinitions": { "nodes": [
+			{ "aliases": ["deploy_tier"], "allowedInConfigFiles": true, "id": "Z2lkOi8vNjAx", "name": "Deploy Tier", "propertyDisplayStatus": "visible", "schema": { "type": "string" } }
+		], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestSeven := autopilot.NewTestRequest(
+		`query ServicePropertiesList($after:String!$first:Int!$service:ID!){account{service(id: $service){properties(after: $after, first: $first){nodes{definition{id,aliases},locked,owner{... on Service{id,aliases}},validationErrors{message,path},value},{{ template "pagination_request" }}}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id3_string" }}" }`,
+		`{ "data": { "account": { "service": { "properties": { "nodes": [
+			{ "definition": { "id": "Z2lkOi8vNjAx", "aliases": ["deploy_tier"] }, "locked": false, "value": "\"canary\"" }
+		], {{ template "no_pagination_response" }} }}}}}`,
+	)
+	testRequestEight := autopilot.NewTestRequest(
+		`query CategoryList($after:String!$first:Int!){account{rubric{categories(after: $after, first: $first){nodes{id,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "categories": { "nodes": [ { "id": "Z2lkOi8vNzAy", "name": "Security" }, { "id": "Z2lkOi8vNzAx", "name": "Reliability" } ] }}}}}`,
+	)
+	testRequestNine := autopilot.NewTestRequest(
+		`{account{rubric{levels{nodes{alias,description,id,index,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{}`,
+		`{ "data": { "account": { "rubric": { "levels": { "nodes": [
+			{ "alias": "silver", "id": "Z2lkOi8vODAy", "index": 2, "name": "Silver" },
+			{ "alias": "bronze", "id": "Z2lkOi8vODAx", "index": 1, "name": "Bronze" }
+		] }}}}}`,
+	)
+	testRequestTen := autopilot.NewTestRequest(
+		`query FilterList($after:String!$first:Int!){account{filters(after: $after, first: $first){nodes{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "filters": { "nodes": [
+			{ "id": "Z2lkOi8vOTAx", "name": "Platform services", "connective": "and", "predicates": [ { "key": "owner_id", "type": "equals", "value": "{{ template "id2_string" }}" } ] }
+		] }}}}`,
+	)
+	testRequestEleven := autopilot.NewTestRequest(
+		`query InfrastructureResourceList($after:String!$all:Boolean!$first:Int!){account{infrastructureResources(after: $after, first: $first){nodes{id,aliases,name,type @include(if: $all),providerResourceType @include(if: $all),providerData @include(if: $all){accountName,externalUrl,providerName},locked,owner @include(if: $all){... on Team{teamAlias:alias,id}},ownerLocked @include(if: $all),data @include(if: $all),rawData @include(if: $all)},{{ template "pagination_request" }}}}}`,
+		`{ {{ template "first_page_variables" }}, "all": true }`,
+		`{ "data": { "account": { "infrastructureResources": { "nodes": [
+			{ "id": "Z2lkOi8vMTAwMQ", "aliases": [], "name": "orders-db", "type": "Database", "owner": { "teamAlias": "platform", {{ template "id2" }} }, "rawData": { "name": "orders-db", "engine": "postgres" } }
+		] }}}}`,
+	)
+	testRequestTwelve := autopilot.NewTestRequest(
+		`{{ template "scorecard_list_query" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "scorecards": { "nodes": [
+			{ "aliases": ["platform_readiness"], "id": "Z2lkOi8vMTEwMQ", "affectsOverallServiceLevels": false, "name": "Platform Readiness", "owner": { "teamAlias": "platform", {{ template "id2" }} }, "filter": { "id": "Z2lkOi8vOTAx", "name": "Platform services" } }
+		] }}}}`,
+	)
+	testRequestThirteen := autopilot.NewTestRequest(
+		`query CheckList($after:String!$first:Int!){account{rubric{checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "checks": { "nodes": [
+			{ "category": { "id": "Z2lkOi8vNzAx", "name": "Reliability" }, "enabled": true, "filter": { "id": "Z2lkOi8vOTAx", "name": "Platform services" }, "id": "Z2lkOi8vMTIwMQ",
+			  "level": { "alias": "bronze", "id": "Z2lkOi8vODAx" }, "name": "Has a tier tag", "owner": { "alias": "platform", {{ template "id2" }} }, "type": "tag_defined", "tagKey": "tier" },
+			{ "category": { "id": "Z2lkOi8vNzAx", "name": "Reliability" }, "enabled": true, "id": "Z2lkOi8vMTIwMw",
+			  "level": { "alias": "bronze", "id": "Z2lkOi8vODAx" }, "name": "Legacy payload", "type": "payload" }
+		] }}}}}`,
+	)
+	testRequestFourteen := autopilot.NewTestRequest(
+		`query ScorecardCheckList($after:String!$first:Int!$scorecard:IdentifierInput!){account{scorecard(input: $scorecard){checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "scorecard": { "id": "Z2lkOi8vMTEwMQ" } }`,
+		`{ "data": { "account": { "scorecard": { "checks": { "nodes": [
+			{ "category": { "id": "Z2lkOi8vMTEwMQ", "name": "Platform Readiness" }, "enabled": true, "id": "Z2lkOi8vMTIwMg",
+			  "level": { "alias": "bronze", "id": "Z2lkOi8vODAx" }, "name": "Has an owner tag", "type": "tag_defined", "tagKey": "owner" }
+		] }}}}}`,
+	)
+	return []autopilot.TestRequest{
+		testRequestOne, testRequestTwo, testRequestThree, testRequestFour, testRequestDomainTags, testRequestFive, testRequestSix,
+		testRequestSeven, testRequestEight, testRequestNine, testRequestTen, testRequestEleven, testRequestTwelve, testRequestThirteen,
+		testRequestFourteen,
+	}
+}
+
+func TestExportSnapshot(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "snapshot/export", exportSnapshotRequests()...)
+	// Act
+	result, err := client.ExportSnapshot()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, ol.SnapshotVersion, result.Version)
+	autopilot.Equals(t, []ol.CatalogTeamSpec{{Alias: "platform", Name: "Platform"}}, result.Catalog.Teams)
+	autopilot.Equals(t, []ol.SnapshotTags{
+		{Kind: ol.CatalogResourceKindDomain, Alias: "commerce", Tags: []ol.TagInput{{Key: "owner", Value: "commerce"}}},
+		{Kind: ol.CatalogResourceKindTeam, Alias: "platform", Tags: []ol.TagInput{{Key: "area", Value: "core"}, {Key: "tier", Value: "1"}}},
+	}, result.Tags)
+	autopilot.Equals(t, []ol.CatalogServiceSpec{{
+		Alias: "checkout",
+		Name:  "Checkout",
+		Owner: "platform",
+		Tier:  "tier_1",
+		Tags:  []ol.TagInput{{Key: "env", Value: "prod"}},
+	}}, result.Catalog.Services)
+	autopilot.Equals(t, []ol.SnapshotUser{
+		{Email: "a@example.com", Name: "A", Role: ol.UserRoleAdmin},
+		{Email: "b@example.com", Name: "B", Role: ol.UserRoleUser},
+	}, result.Users)
+	autopilot.Equals(t, []ol.SnapshotTeam{{
+		Alias:    "platform",
+		Members:  []ol.SnapshotTeamMember{{Email: "a@example.com"}, {Email: "b@example.com", Role: "admin"}},
+		Contacts: []ol.SnapshotContact{{Type: ol.ContactTypeSlack, Address: "#platform", DisplayName: "Platform"}},
+	}}, result.Teams)
+	autopilot.Equals(t, "deploy_tier", result.PropertyDefinitions[0].Alias)
+	autopilot.Equals(t, map[string]any{"type": "string"}, result.PropertyDefinitions[0].Schema)
+	autopilot.Equals(t, []ol.SnapshotServiceProperties{{Service: "checkout", Values: map[string]any{"deploy_tier": "canary"}}}, result.Properties)
+	autopilot.Equals(t, []ol.SnapshotCategory{{Name: "Reliability"}, {Name: "Security"}}, result.Categories)
+	autopilot.Equals(t, "bronze", result.Levels[0].Alias)
+	autopilot.Equals(t, "silver", result.Levels[1].Alias)
+	autopilot.Equals(t, "platform", result.Filters[0].Predicates[0].Value)
+	autopilot.Equals(t, []ol.SnapshotInfrastructure{{
+		Name:   "orders-db",
+		Schema: "Database",
+		Owner:  "platform",
+		Data:   map[string]any{"name": "orders-db", "engine": "postgres"},
+	}}, result.Infrastructure)
+	autopilot.Equals(t, "Platform services", result.Scorecards[0].Filter)
+	autopilot.Equals(t, []ol.SnapshotCheck{{
+		Name:     "Has a tier tag",
+		Type:     ol.CheckTypeTagDefined,
+		Category: "Reliability",
+		Level:    "bronze",
+		Owner:    "platform",
+		Filter:   "Platform services",
+		Enabled:  true,
+		Config:   map[string]any{"tagKey": "tier"},
+	}, {
+		Name:      "Has an owner tag",
+		Type:      ol.CheckTypeTagDefined,
+		Scorecard: "platform_readiness",
+		Level:     "bronze",
+		Enabled:   true,
+		Config:    map[string]any{"tagKey": "owner"},
+	}}, result.Checks)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindCheck, Name: "Legacy payload"}}, result.Skipped)
+}
+
+func TestSnapshotRoundTripScorecardChecks(t *testing.T) {
+	// Arrange
+	exported, err := BestTestClient(t, "snapshot/round_trip_export", exportSnapshotRequests()...).ExportSnapshot()
+	autopilot.Ok(t, err)
+	dir := t.TempDir()
+	autopilot.Ok(t, ol.WriteSnapshotDir(dir, exported))
+	read, err := ol.ReadSnapshotDir(dir)
+	autopilot.Ok(t, err)
+	snapshot := ol.Snapshot{
+		Version:    read.Version,
+		Scorecards: read.Scorecards,
+		Checks:     slices.DeleteFunc(read.Checks, func(check ol.SnapshotCheck) bool { return check.Scorecard == "" }),
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`{{ template "scorecard_list_query" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "scorecards": { "nodes": [
+			{ "aliases": ["platform_readiness"], {{ template "id1" }}, "name": "Platform Readiness", "owner": { "teamAlias": "platform", {{ template "id2" }} } }
+		] }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query ScorecardCheckList($after:String!$first:Int!$scorecard:IdentifierInput!){account{scorecard(input: $scorecard){checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "scorecard": { {{ template "id1" }} } }`,
+		`{ "data": { "account": { "scorecard": { "checks": { "nodes": [] }}}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`{account{rubric{levels{nodes{alias,description,id,index,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{}`,
+		`{ "data": { "account": { "rubric": { "levels": { "nodes": [ { "alias": "bronze", {{ template "id3" }}, "index": 1, "name": "Bronze" } ] }}}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		BuildCheckMutation("TagDefined", CreateRequest),
+		`{ "input": { "name": "Has an owner tag", "enabled": true, "categoryId": "{{ template "id1_string" }}", "levelId": "{{ template "id3_string" }}", "tagKey": "owner" } }`,
+		BuildCheckMutationResponse("TagDefined", CreateRequest, map[string]any{"name": "Has an owner tag", "tagKey": "owner"}),
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree, testRequestFour}
+
+	client := BestTestClient(t, "snapshot/round_trip_restore", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindCheck, Name: "Has an owner tag"}}, result.Created)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindScorecard, Name: "platform_readiness"}}, result.Existing)
+}
+
+func TestSnapshotCheckConfig(t *testing.T) {
+	// Arrange
+	testCases := map[string]struct {
+		check    ol.Check
+		expected map[string]any
+	}{
+		"git branch protection": {
+			check:    ol.Check{Type: ol.CheckTypeGitBranchProtection},
+			expected: nil,
+		},
+		"repo file": {
+			check: ol.Check{
+				Type: ol.CheckTypeRepoFile,
+				RepositoryFileCheckFragment: ol.RepositoryFileCheckFragment{
+					Filepaths:             []string{"README.md"},
+					FileContentsPredicate: &ol.Predicate{Type: ol.PredicateTypeEnumContains, Value: "owner"},
+				},
+			},
+			expected: map[string]any{
+				"directorySearch":       false,
+				"filePaths":             []any{"README.md"},
+				"fileContentsPredicate": map[string]any{"type": "contains", "value": "owner"},
+				"useAbsoluteRoot":       false,
+			},
+		},
+		"service property": {
+			check: ol.Check{
+				Type: ol.CheckTypeServiceProperty,
+				ServicePropertyCheckFragment: ol.ServicePropertyCheckFragment{
+					Property:           ol.ServicePropertyTypeEnumCustomProperty,
+					PropertyDefinition: &ol.PropertyDefinition{Aliases: []string{"deploy_tier"}, Id: id1},
+					Predicate:          &ol.Predicate{Type: ol.PredicateTypeEnumExists},
+				},
+			},
+			expected: map[string]any{
+				"serviceProperty":        "custom_property",
+				"propertyDefinition":     map[string]any{"alias": "deploy_tier"},
+				"propertyValuePredicate": map[string]any{"type": "exists"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result, err := ol.SnapshotCheckConfig(testCase.check)
+			// Assert
+			autopilot.Ok(t, err)
+			autopilot.Equals(t, testCase.expected, result)
+		})
+	}
+}
+
+func TestSnapshotCheckConfigUnsupported(t *testing.T) {
+	// Act
+	_, err := ol.SnapshotCheckConfig(ol.Check{Name: "Legacy", Type: ol.CheckTypePayload})
+	// Assert
+	autopilot.Equals(t, "check 'Legacy' of type 'payload' can not be exported", err.Error())
+}
+
+func TestSnapshotDir(t *testing.T) {
+	// Arrange
+	snapshot := &ol.Snapshot{
+		Version: ol.SnapshotVersion,
+		Catalog: ol.CatalogSpec{
+			Version: 1,
+			Teams:   []ol.CatalogTeamSpec{{Alias: "platform", Name: "Platform"}},
+		},
+		Users:      []ol.SnapshotUser{{Email: "a@example.com", Name: "A", Role: ol.UserRoleAdmin}},
+		Categories: []ol.SnapshotCategory{{Name: "Reliability"}},
+		Filters: []ol.SnapshotFilter{{
+			Name:       "Platform services",
+			Connective: ol.ConnectiveEnumAnd,
+			Predicates: []ol.FilterPredicate{{Key: ol.PredicateKeyEnumOwnerID, Type: ol.PredicateTypeEnumEquals, Value: "platform"}},
+		}},
+		Checks: []ol.SnapshotCheck{{
+			Name:     "Has a tier tag",
+			Type:     ol.CheckTypeTagDefined,
+			Category: "Reliability",
+			Level:    "bronze",
+			Enabled:  true,
+			Config:   map[string]any{"tagKey": "tier"},
+		}},
+	}
+	first, second := t.TempDir(), t.TempDir()
+	// Act
+	autopilot.Ok(t, ol.WriteSnapshotDir(first, snapshot))
+	result, err := ol.ReadSnapshotDir(first)
+	autopilot.Ok(t, err)
+	autopilot.Ok(t, ol.WriteSnapshotDir(second, result))
+	// Assert
+	autopilot.Equals(t, snapshot.Version, result.Version)
+	autopilot.Equals(t, snapshot.Catalog, result.Catalog)
+	autopilot.Equals(t, snapshot.Users, result.Users)
+	autopilot.Equals(t, snapshot.Categories, result.Categories)
+	autopilot.Equals(t, snapshot.Filters, result.Filters)
+	autopilot.Equals(t, snapshot.Checks, result.Checks)
+	for _, name := range []string{"snapshot.yaml", "catalog.yaml", "users.yaml", "filters.yaml", "checks.yaml"} {
+		expected, err := os.ReadFile(filepath.Join(first, name))
+		autopilot.Ok(t, err)
+		actual, err := os.ReadFile(filepath.Join(second, name))
+		autopilot.Ok(t, err)
+		autopilot.Equals(t, string(expected), string(actual))
+	}
+}
+
+func TestReadSnapshotDirVersion(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	autopilot.Ok(t, os.WriteFile(filepath.Join(dir, "snapshot.yaml"), []byte("version: 2\n"), 0o644))
+	// Act
+	_, err := ol.ReadSnapshotDir(dir)
+	// Assert
+	autopilot.Equals(t, "snapshot version must be 1. Given: '2'", err.Error())
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	// Arrange
+	snapshot := ol.Snapshot{
+		Version: ol.SnapshotVersion,
+		Users: []ol.SnapshotUser{
+			{Email: "existing@example.com", Name: "Existing", Role: ol.UserRoleUser},
+			{Email: "new@example.com", Name: "New", Role: ol.UserRoleUser},
+		},
+		Categories: []ol.SnapshotCategory{{Name: "Performance"}, {Name: "Reliability"}},
+		Levels:     []ol.SnapshotLevel{{Alias: "bronze", Name: "Bronze", Index: 1}},
+		Filters: []ol.SnapshotFilter{{
+			Name:       "Platform services",
+			Connective: ol.ConnectiveEnumAnd,
+			Predicates: []ol.FilterPredicate{{Key: ol.PredicateKeyEnumOwnerID, Type: ol.PredicateTypeEnumEquals, Value: "platform"}},
+		}},
+		Checks: []ol.SnapshotCheck{{
+			Name:     "Has a tier tag",
+			Type:     ol.CheckTypeTagDefined,
+			Category: "Reliability",
+			Level:    "bronze",
+			Owner:    "platform",
+			Filter:   "Platform services",
+			Enabled:  true,
+			Config:   map[string]any{"tagKey": "tier"},
+		}},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`query UserList($after:String!$first:Int!){account{users(after: $after, first: $first){nodes{id,email,deactivatedAt,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "users": { "nodes": [ { "id": "Z2lkOi8vNTAx", "email": "Existing@example.com", "name": "Existing", "role": "user" } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation UserInvite($email:String!$input:UserInput!){userInvite(email: $email input: $input){user{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
+		`{ "email": "new@example.com", "input": { "name": "New", "role": "user", "skipWelcomeEmail": true } }`,
+		`{ "data": { "userInvite": { "user": { "id": "Z2lkOi8vNTAy", "email": "new@example.com", "name": "New", "role": "user" }, "errors": [] }}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`query CategoryList($after:String!$first:Int!){account{rubric{categories(after: $after, first: $first){nodes{id,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "categories": { "nodes": [ { {{ template "id1" }}, "name": "Performance" } ] }}}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`mutation CategoryCreate($input:CategoryCreateInput!){categoryCreate(input: $input){category{id,name},errors{message,path}}}`,
+		`{ "input": { "name": "Reliability" } }`,
+		`{ "data": { "categoryCreate": { "category": { {{ template "id2" }}, "name": "Reliability" }, "errors": [] }}}`,
+	)
+	testRequestFive := autopilot.NewTestRequest(
+		`{account{rubric{levels{nodes{alias,description,id,index,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{}`,
+		`{ "data": { "account": { "rubric": { "levels": { "nodes": [ { "alias": "bronze", {{ template "id3" }}, "index": 1, "name": "Bronze" } ] }}}}}`,
+	)
+	testRequestSix := autopilot.NewTestRequest(
+		`query FilterList($after:String!$first:Int!){account{filters(after: $after, first: $first){nodes{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},{{ template "pagination_request" }},totalCount}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "filters": { "nodes": [] }}}}`,
+	)
+	testRequestSeven := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [ { "alias": "platform", {{ template "id4" }}, "aliases": ["platform"], "name": "Platform" } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestEight := autopilot.NewTestRequest(
+		`mutation FilterCreate($input:FilterCreateInput!){filterCreate(input: $input){filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},errors{message,path}}}`,
+		`{ "input": { "name": "Platform services", "connective": "and", "predicates": [ { "key": "owner_id", "type": "equals", "value": "{{ template "id4_string" }}" } ] } }`,
+		`{ "data": { "filterCreate": { "filter": { "id": "Z2lkOi8vOTAx", "name": "Platform services", "connective": "and", "predicates": [ { "key": "owner_id", "type": "equals", "value": "{{ template "id4_string" }}" } ] }, "errors": [] }}}`,
+	)
+	testRequestNine := autopilot.NewTestRequest(
+		`query CheckList($after:String!$first:Int!){account{rubric{checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "checks": { "nodes": [] }}}}}`,
+	)
+	testRequestTen := autopilot.NewTestRequest(
+		BuildCheckMutation("TagDefined", CreateRequest),
+		`{ "input": { "name": "Has a tier tag", "enabled": true, "categoryId": "{{ template "id2_string" }}", "levelId": "{{ template "id3_string" }}", "ownerId": "{{ template "id4_string" }}", "filterId": "Z2lkOi8vOTAx", "tagKey": "tier" } }`,
+		BuildCheckMutationResponse("TagDefined", CreateRequest, map[string]any{"name": "Has a tier tag", "tagKey": "tier"}),
+	)
+	requests := []autopilot.TestRequest{
+		testRequestOne, testRequestTwo, testRequestThree, testRequestFour, testRequestFive,
+		testRequestSix, testRequestSeven, testRequestEight, testRequestNine, testRequestTen,
+	}
+
+	client := BestTestClient(t, "snapshot/restore", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.SnapshotResource{
+		{Kind: ol.SnapshotResourceKindUser, Name: "new@example.com"},
+		{Kind: ol.SnapshotResourceKindCategory, Name: "Reliability"},
+		{Kind: ol.SnapshotResourceKindFilter, Name: "Platform services"},
+		{Kind: ol.SnapshotResourceKindCheck, Name: "Has a tier tag"},
+	}, result.Created)
+	autopilot.Equals(t, []ol.SnapshotResource{
+		{Kind: ol.SnapshotResourceKindUser, Name: "existing@example.com"},
+		{Kind: ol.SnapshotResourceKindCategory, Name: "Performance"},
+		{Kind: ol.SnapshotResourceKindLevel, Name: "bronze"},
+	}, result.Existing)
+}
+
+func TestRestoreSnapshotMissingReference(t *testing.T) {
+	// Arrange
+	snapshot := ol.Snapshot{
+		Version: ol.SnapshotVersion,
+		Checks:  []ol.SnapshotCheck{{Name: "Has a tier tag", Type: ol.CheckTypeTagDefined, Category: "Reliability", Level: "bronze"}},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`query CheckList($after:String!$first:Int!){account{rubric{checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "checks": { "nodes": [] }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query CategoryList($after:String!$first:Int!){account{rubric{categories(after: $after, first: $first){nodes{id,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "categories": { "nodes": [] }}}}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "snapshot/restore_missing_reference", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{})
+	// Assert
+	autopilot.Equals(t, "check 'Has a tier tag': category 'Reliability' not found", err.Error())
+	autopilot.Equals(t, 0, len(result.Created))
+}
+
+// restoreSnapshotTeamsRequests returns a snapshot adding a member and a contact to a team that has one of each already,
+// with the requests to list the team and add them
+func restoreSnapshotTeamsRequests() (ol.Snapshot, autopilot.TestRequest, autopilot.TestRequest, autopilot.TestRequest) {
+	snapshot := ol.Snapshot{
+		Version: ol.SnapshotVersion,
+		Teams: []ol.SnapshotTeam{{
+			Alias:    "platform",
+			Members:  []ol.SnapshotTeamMember{{Email: "new@example.com"}},
+			Contacts: []ol.SnapshotContact{{Type: ol.ContactTypeEmail, Address: "platform@example.com"}},
+		}},
+	}
+	teamList := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [ { "alias": "platform", {{ template "id1" }}, "aliases": ["platform"], "name": "Platform",
+			"contacts": [ { {{ template "id2" }}, "type": "slack", "address": "#platform", "displayName": "Platform" } ],
+			"memberships": { "nodes": [
+				{ "role": "member", "team": { "alias": "platform", {{ template "id1" }} }, "user": { {{ template "id3" }}, "email": "existing@example.com" } }
+			] } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	membershipCreate := autopilot.NewTestRequest(
+		`mutation TeamMembershipCreate($input:TeamMembershipCreateInput!){teamMembershipCreate(input: $input){memberships{role,team{alias,id},user{id,email}},errors{message,path}}}`,
+		`{"input": { "teamId": "{{ template "id1_string" }}", "members": [ { "user": { "email": "new@example.com" } } ] }}`,
+		`{"data": {"teamMembershipCreate": {"memberships": [ { "role": "member", "team": { "alias": "platform", {{ template "id1" }} }, "user": { {{ template "id4" }}, "email": "new@example.com" } } ], "errors": [] }}}`,
+	)
+	contactCreate := autopilot.NewTestRequest(
+		`mutation ContactCreate($input:ContactCreateInput!){contactCreate(input: $input){contact{address,displayName,displayType,externalId,id,isDefault,type},errors{message,path}}}`,
+		`{"input": {"type": "email", "address": "platform@example.com", "ownerId": "{{ template "id1_string" }}" }}`,
+		`{"data": {"contactCreate": {"contact": {"address": "platform@example.com", "id": "Z2lkOi8vNTU1", "type": "email"}, "errors": [] } }}`,
+	)
+	return snapshot, teamList, membershipCreate, contactCreate
+}
+
+func TestRestoreSnapshotTeamsKeepsExtraMembersAndContacts(t *testing.T) {
+	// Arrange
+	snapshot, teamList, membershipCreate, contactCreate := restoreSnapshotTeamsRequests()
+	requests := []autopilot.TestRequest{teamList, membershipCreate, contactCreate}
+
+	client := BestTestClient(t, "snapshot/restore_teams", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindTeam, Name: "platform"}}, result.Updated)
+}
+
+func TestRestoreSnapshotTeamsPrune(t *testing.T) {
+	// Arrange
+	snapshot, teamList, membershipCreate, contactCreate := restoreSnapshotTeamsRequests()
+	membershipDelete := autopilot.NewTestRequest(
+		`mutation TeamMembershipDelete($input:TeamMembershipDeleteInput!){teamMembershipDelete(input: $input){deletedMembers{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
+		`{"input": { "teamId": "{{ template "id1_string" }}", "members": [ { "user": { "email": "existing@example.com" }, "role": "member" } ] }}`,
+		`{"data": {"teamMembershipDelete": {"deletedMembers": [ { {{ template "id3" }}, "email": "existing@example.com" } ], "errors": [] }}}`,
+	)
+	contactDelete := autopilot.NewTestRequest(
+		`mutation ContactDelete($input:ContactDeleteInput!){contactDelete(input: $input){deletedContactId,errors{message,path}}}`,
+		`{"input": { {{ template "id2" }} }}`,
+		`{"data": {"contactDelete": {"deletedContactId": "{{ template "id2_string" }}", "errors": [] } }}`,
+	)
+	requests := []autopilot.TestRequest{teamList, membershipDelete, membershipCreate, contactDelete, contactCreate}
+
+	client := BestTestClient(t, "snapshot/restore_teams_prune", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{PruneTeams: true})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindTeam, Name: "platform"}}, result.Updated)
+}
+
+func TestRestoreSnapshotMatchesLevelsAndPropertyDefinitionsByName(t *testing.T) {
+	// Arrange
+	snapshot := ol.Snapshot{
+		Version: ol.SnapshotVersion,
+		Levels: []ol.SnapshotLevel{
+			{Alias: "silver", Name: "Silver", Index: 2},
+			{Alias: "bronze_tier", Name: "Bronze", Index: 1},
+		},
+		PropertyDefinitions: []ol.SnapshotPropertyDefinition{
+			{Alias: "deploy_tier", Name: "Deploy Tier", Schema: map[string]any{"type": "string"}},
+		},
+		Properties: []ol.SnapshotServiceProperties{{Service: "checkout", Values: map[string]any{"deploy_tier": "canary"}}},
+		Checks: []ol.SnapshotCheck{{
+			Name:     "Has a deploy tier",
+			Type:     ol.CheckTypeServiceProperty,
+			Category: "Reliability",
+			Level:    "silver",
+			Enabled:  true,
+			Config:   map[string]any{"serviceProperty": "custom_property", "propertyDefinition": map[string]any{"alias": "deploy_tier"}},
+		}},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`{account{rubric{levels{nodes{alias,description,id,index,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{}`,
+		`{ "data": { "account": { "rubric": { "levels": { "nodes": [
+			{ "alias": "bronze", {{ template "id1" }}, "index": 1, "name": "Bronze" },
+			{ "alias": "gold", {{ template "id2" }}, "index": 2, "name": "Gold" }
+		] }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation LevelCreate($input:LevelCreateInput!){levelCreate(input: $input){level{alias,description,id,index,name},errors{message,path}}}`,
+		`{ "input": { "name": "Silver", "index": 3 } }`,
+		`{ "data": { "levelCreate": { "level": { "alias": "silver_2", {{ template "id3" }}, "index": 3, "name": "Silver" }, "errors": [] }}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`query PropertyDefinitionList($after:String!$first:Int!){account{propertyDefinitions(after: $after, first: $first){nodes{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},{{ template "pagination_request" }}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "propertyDefinitions": { "nodes": [], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`mutation PropertyDefinitionCreate($input:PropertyDefinitionInput!){propertyDefinitionCreate(input: $input){definition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},errors{message,path}}}`,
+		`{ "input": { "name": "Deploy Tier", "schema": "{\"type\":\"string\"}", "allowedInConfigFiles": false } }`,
+		`{ "data": { "propertyDefinitionCreate": { "definition": { "aliases": ["deploy_tier_2"], {{ template "id4" }}, "name": "Deploy Tier", "schema": { "type": "string" } }, "errors": [] }}}`,
+	)
+	testRequestFive := autopilot.NewTestRequest(
+		`query ServiceGet($service:String!){account{service(alias: $service){id,aliases}}}`,
+		`{ "service": "checkout" }`,
+		`{ "data": { "account": { "service": { "id": "Z2lkOi8vMzAx", "aliases": ["checkout"] } }}}`,
+	)
+	testRequestSix := autopilot.NewTestRequest(
+		`query ServicePropertiesList($after:String!$first:Int!$service:ID!){account{service(id: $service){properties(after: $after, first: $first){nodes{definition{id,aliases},locked,owner{... on Service{id,aliases}},validationErrors{message,path},value},{{ template "pagination_request" }}}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "Z2lkOi8vMzAx" }`,
+		`{ "data": { "account": { "service": { "properties": { "nodes": [
+			{ "definition": { "id": "Z2lkOi8vNjA5", "aliases": ["legacy"] }, "locked": false, "value": "{\"kept\":true}" }
+		], {{ template "no_pagination_response" }} }}}}}`,
+	)
+	testRequestSeven := autopilot.NewTestRequest(
+		`mutation PropertyAssign($input:PropertyInput!){propertyAssign(input: $input){property{definition{id,aliases},locked,owner{... on Service{id,aliases}},validationErrors{message,path},value},errors{message,path}}}`,
+		`{ "input": { "owner": { "id": "Z2lkOi8vMzAx" }, "definition": { {{ template "id4" }} }, "value": "\"canary\"" } }`,
+		`{ "data": { "propertyAssign": { "property": { "definition": { {{ template "id4" }}, "aliases": ["deploy_tier_2"] }, "locked": false, "value": "\"canary\"" }, "errors": [] }}}`,
+	)
+	testRequestEight := autopilot.NewTestRequest(
+		`query CheckList($after:String!$first:Int!){account{rubric{checks(after: $after, first: $first){nodes{category{id,name},description,enableOn,enabled,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{frequencyTimeScale,frequencyValue,startingDate},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyDefinition{aliases,allowedInConfigFiles,id,name,description,displaySubtype,displayType,propertyDisplayStatus,schema},propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype},... on PackageVersionCheck{missingPackageResult,packageConstraint,packageManager,packageName,packageNameIsRegex,versionConstraintPredicate{type,value}}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "checks": { "nodes": [] }}}}}`,
+	)
+	testRequestNine := autopilot.NewTestRequest(
+		`query CategoryList($after:String!$first:Int!){account{rubric{categories(after: $after, first: $first){nodes{id,name},{{ template "pagination_request" }},totalCount}}}}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "rubric": { "categories": { "nodes": [ { "id": "Z2lkOi8vNzAx", "name": "Reliability" } ] }}}}}`,
+	)
+	testRequestTen := autopilot.NewTestRequest(
+		BuildCheckMutation("ServiceProperty", CreateRequest),
+		`{ "input": { "name": "Has a deploy tier", "enabled": true, "categoryId": "Z2lkOi8vNzAx", "levelId": "{{ template "id3_string" }}", "serviceProperty": "custom_property", "propertyDefinition": { {{ template "id4" }} } } }`,
+		BuildCheckMutationResponse("ServiceProperty", CreateRequest, map[string]any{"name": "Has a deploy tier", "serviceProperty": "custom_property"}),
+	)
+	requests := []autopilot.TestRequest{
+		testRequestOne, testRequestTwo, testRequestThree, testRequestFour, testRequestFive,
+		testRequestSix, testRequestSix, testRequestSeven, testRequestEight, testRequestNine, testRequestTen,
+	}
+
+	client := BestTestClient(t, "snapshot/restore_match_by_name", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.SnapshotResource{
+		{Kind: ol.SnapshotResourceKindLevel, Name: "silver"},
+		{Kind: ol.SnapshotResourceKindPropertyDefinition, Name: "deploy_tier"},
+		{Kind: ol.SnapshotResourceKindCheck, Name: "Has a deploy tier"},
+	}, result.Created)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindLevel, Name: "bronze_tier"}}, result.Existing)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindProperty, Name: "checkout"}}, result.Updated)
+}
+
+func TestRestoreSnapshotPruneProperties(t *testing.T) {
+	// Arrange
+	snapshot := ol.Snapshot{
+		Version:    ol.SnapshotVersion,
+		Properties: []ol.SnapshotServiceProperties{{Service: "checkout", Values: map[string]any{}}},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceGet($service:String!){account{service(alias: $service){id,aliases}}}`,
+		`{ "service": "checkout" }`,
+		`{ "data": { "account": { "service": { "id": "Z2lkOi8vMzAx", "aliases": ["checkout"] } }}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query ServicePropertiesList($after:String!$first:Int!$service:ID!){account{service(id: $service){properties(after: $after, first: $first){nodes{definition{id,aliases},locked,owner{... on Service{id,aliases}},validationErrors{message,path},value},{{ template "pagination_request" }}}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "Z2lkOi8vMzAx" }`,
+		`{ "data": { "account": { "service": { "properties": { "nodes": [
+			{ "definition": { "id": "Z2lkOi8vNjA5", "aliases": ["legacy"] }, "locked": false, "value": "true" }
+		], {{ template "no_pagination_response" }} }}}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation PropertyUnassign($definition:IdentifierInput!$owner:IdentifierInput!){propertyUnassign(owner: $owner, definition: $definition){errors{message,path}}}`,
+		`{ "owner": { "id": "Z2lkOi8vMzAx" }, "definition": { "id": "Z2lkOi8vNjA5" } }`,
+		`{ "data": { "propertyUnassign": { "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "snapshot/restore_prune_properties", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{PruneProperties: true})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.SnapshotResource{{Kind: ol.SnapshotResourceKindProperty, Name: "checkout"}}, result.Updated)
+}
+
+func TestRestoreSnapshotTags(t *testing.T) {
+	// Arrange
+	snapshot := ol.Snapshot{
+		Version: ol.SnapshotVersion,
+		Tags: []ol.SnapshotTags{
+			{Kind: ol.CatalogResourceKindDomain, Alias: "commerce", Tags: []ol.TagInput{{Key: "owner", Value: "commerce"}, {Key: "tier", Value: "1"}}},
+			{Kind: ol.CatalogResourceKindTeam, Alias: "platform", Tags: []ol.TagInput{{Key: "area", Value: "core"}}},
+		},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`{{ template "catalog_domain_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "domains": { "nodes": [ { "id": "Z2lkOi8vMTMwMQ", "aliases": ["commerce"], "name": "Commerce" } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`query DomainTagsList($after:String!$domain:IdentifierInput!$first:Int!){account{domain(input: $domain){tags(after: $after, first: $first){nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "domain": { "id": "Z2lkOi8vMTMwMQ" } }`,
+		`{ "data": { "account": { "domain": { "tags": { "nodes": [ { "id": "Z2lkOi8vMTMwNA", "key": "owner", "value": "commerce" } ], {{ template "no_pagination_response" }} }}}}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation TagAssign($input:TagAssignInput!){tagAssign(input: $input){tags{id,key,value},errors{message,path}}}`,
+		`{ "input": { "id": "Z2lkOi8vMTMwMQ", "tags": [ { "key": "tier", "value": "1" } ] } }`,
+		`{ "data": { "tagAssign": { "tags": [ { "id": "Z2lkOi8vMTMwNQ", "key": "tier", "value": "1" } ], "errors": [] }}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`{{ template "catalog_team_list_request" }}`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "teams": { "nodes": [ { "alias": "platform", {{ template "id1" }}, "aliases": ["platform"], "name": "Platform",
+			"tags": { "nodes": [], {{ template "no_pagination_response" }} } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	testRequestFive := autopilot.NewTestRequest(
+		`mutation TagAssign($input:TagAssignInput!){tagAssign(input: $input){tags{id,key,value},errors{message,path}}}`,
+		`{ "input": { {{ template "id1" }}, "tags": [ { "key": "area", "value": "core" } ] } }`,
+		`{ "data": { "tagAssign": { "tags": [ { "id": "Z2lkOi8vMTMwNg", "key": "area", "value": "core" } ], "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree, testRequestFour, testRequestFive}
+
+	client := BestTestClient(t, "snapshot/restore_tags", requests...)
+	// Act
+	result, err := client.RestoreSnapshot(snapshot, ol.SnapshotRestoreOptions{})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.SnapshotResource{
+		{Kind: ol.SnapshotResourceKindTag, Name: "commerce tier:1"},
+		{Kind: ol.SnapshotResourceKindTag, Name: "platform area:core"},
+	}, result.Created)
+}
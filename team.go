@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"html"
 	"slices"
+	"strings"
 )
 
 type Contact struct {
@@ -92,6 +93,212 @@ func (team *Team) ReconcileAliases(client *Client, aliasesWanted []string) error
 	return errors.Join(deleteErr, createErr, getErr)
 }
 
+// TeamMembershipReconcileResult lists the memberships ReconcileMemberships changed
+type TeamMembershipReconcileResult struct {
+	Added       []TeamMembershipUserInput
+	Removed     []TeamMembershipUserInput
+	RoleChanged []TeamMembershipUserInput // Memberships removed and added back with the desired role
+	Dropped     []TeamMembershipUserInput // Memberships removed to change their role that could not be added back
+}
+
+func (result *TeamMembershipReconcileResult) IsEmpty() bool {
+	return len(result.Added) == 0 && len(result.Removed) == 0 && len(result.RoleChanged) == 0 && len(result.Dropped) == 0
+}
+
+// ReconcileMemberships makes the team's members match 'membershipsWanted'
+//
+// Users are matched by id or email. Members not in 'membershipsWanted' are removed, new members are added
+// and members whose role differs are removed and added back with the wanted role, a nil Role leaves the current role as is.
+// When adding fails the members removed to change their role are listed in Dropped and returned with the error.
+func (team *Team) ReconcileMemberships(client *Client, membershipsWanted []TeamMembershipUserInput) (*TeamMembershipReconcileResult, error) {
+	if team.Id == "" {
+		return nil, fmt.Errorf("unable to reconcile memberships, invalid team id: '%s'", team.Id)
+	}
+	for i, wanted := range membershipsWanted {
+		if wanted.User == nil || (wanted.User.Id == nil && wanted.User.Email == nil) {
+			return nil, fmt.Errorf("membership %d must identify a user by id or email", i)
+		}
+	}
+	if team.Memberships == nil || team.Memberships.PageInfo.HasNextPage {
+		team.Memberships = nil
+		if _, err := team.GetMemberships(client, nil); err != nil {
+			return nil, err
+		}
+	}
+	users, err := team.resolveMembershipUsers(client, membershipsWanted)
+	if err != nil {
+		return nil, err
+	}
+	for i, user := range users {
+		if slices.Contains(users[:i], user) {
+			return nil, fmt.Errorf("user '%s' is wanted more than once", membershipsWanted[i].User)
+		}
+	}
+
+	result := &TeamMembershipReconcileResult{}
+	var toRemove []TeamMembershipUserInput
+	for _, membership := range team.Memberships.Nodes {
+		index := slices.IndexFunc(membershipsWanted, func(wanted TeamMembershipUserInput) bool { return membership.User.matches(wanted.User) })
+		switch {
+		case index < 0:
+			result.Removed = append(result.Removed, membership.input())
+			toRemove = append(toRemove, membership.input())
+		case membershipsWanted[index].Role != nil && *membershipsWanted[index].Role != membership.Role:
+			result.RoleChanged = append(result.RoleChanged, membershipsWanted[index])
+			toRemove = append(toRemove, membership.input())
+		}
+	}
+	for _, wanted := range membershipsWanted {
+		if !slices.ContainsFunc(team.Memberships.Nodes, func(membership TeamMembership) bool { return membership.User.matches(wanted.User) }) {
+			result.Added = append(result.Added, wanted)
+		}
+	}
+
+	if len(toRemove) > 0 {
+		if _, err := client.RemoveMemberships(&team.TeamId, toRemove...); err != nil {
+			return nil, err
+		}
+		team.Memberships.Nodes = slices.DeleteFunc(team.Memberships.Nodes, func(membership TeamMembership) bool {
+			return slices.ContainsFunc(toRemove, func(removed TeamMembershipUserInput) bool { return membership.User.matches(removed.User) })
+		})
+		team.Memberships.TotalCount = len(team.Memberships.Nodes)
+	}
+	toAdd := append(slices.Clone(result.Added), result.RoleChanged...)
+	if len(toAdd) > 0 {
+		added, err := client.AddMemberships(&team.TeamId, toAdd...)
+		if err != nil {
+			result.Dropped, result.Added, result.RoleChanged = result.RoleChanged, nil, nil
+			if len(result.Dropped) > 0 {
+				dropped := make([]string, len(result.Dropped))
+				for i, membership := range result.Dropped {
+					dropped[i] = membership.User.String()
+				}
+				return result, fmt.Errorf("unable to add back users removed to change their role '%s': %w", strings.Join(dropped, "', '"), err)
+			}
+			return result, err
+		}
+		team.Memberships.Nodes = append(team.Memberships.Nodes, added...)
+	}
+	team.Memberships.TotalCount = len(team.Memberships.Nodes)
+	return result, nil
+}
+
+// resolveMembershipUsers returns the user id of every wanted membership so the same user given once by id and
+// once by email resolves to the same key. Users that are not members yet are only looked up when ids and emails are mixed.
+func (team *Team) resolveMembershipUsers(client *Client, membershipsWanted []TeamMembershipUserInput) ([]ID, error) {
+	output := make([]ID, len(membershipsWanted))
+	var byId, byEmail []int
+	for i, wanted := range membershipsWanted {
+		index := slices.IndexFunc(team.Memberships.Nodes, func(membership TeamMembership) bool { return membership.User.matches(wanted.User) })
+		switch {
+		case index >= 0:
+			output[i] = team.Memberships.Nodes[index].User.Id
+		case wanted.User.Id != nil && *wanted.User.Id != "":
+			output[i] = *wanted.User.Id
+			byId = append(byId, i)
+		default:
+			output[i] = ID(strings.ToLower(*wanted.User.Email))
+			byEmail = append(byEmail, i)
+		}
+	}
+	if len(byId) == 0 {
+		return output, nil
+	}
+	for _, i := range byEmail {
+		user, err := client.GetUser(*membershipsWanted[i].User.Email)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve user '%s': %w", membershipsWanted[i].User, err)
+		}
+		if user.Id != "" {
+			output[i] = user.Id
+		}
+	}
+	return output, nil
+}
+
+func (membership TeamMembership) input() TeamMembershipUserInput {
+	user := &UserIdentifierInput{}
+	if membership.User.Email != "" {
+		user.Email = RefOf(membership.User.Email)
+	} else {
+		user.Id = RefOf(membership.User.Id)
+	}
+	return TeamMembershipUserInput{User: user, Role: RefOf(membership.Role)}
+}
+
+// TeamContactReconcileResult lists the contacts ReconcileContacts changed
+type TeamContactReconcileResult struct {
+	Added   []Contact
+	Updated []Contact
+	Removed []Contact
+}
+
+func (result *TeamContactReconcileResult) IsEmpty() bool {
+	return len(result.Added) == 0 && len(result.Updated) == 0 && len(result.Removed) == 0
+}
+
+// ReconcileContacts makes the team's contacts match 'contactsWanted'
+//
+// Contacts are matched by type and address. Contacts not in 'contactsWanted' are removed, new contacts
+// are added and the display name of matching contacts is updated, a nil DisplayName leaves it as is.
+func (team *Team) ReconcileContacts(client *Client, contactsWanted []ContactInput) (*TeamContactReconcileResult, error) {
+	if team.Id == "" {
+		return nil, fmt.Errorf("unable to reconcile contacts, invalid team id: '%s'", team.Id)
+	}
+	for i, wanted := range contactsWanted {
+		if slices.ContainsFunc(contactsWanted[:i], func(other ContactInput) bool { return other.Type == wanted.Type && other.Address == wanted.Address }) {
+			return nil, fmt.Errorf("%s contact '%s' is wanted more than once", wanted.Type, wanted.Address)
+		}
+	}
+
+	var allErrors error
+	result := &TeamContactReconcileResult{}
+	contacts := []Contact{}
+	for _, contact := range team.Contacts {
+		index := slices.IndexFunc(contactsWanted, contact.matches)
+		if index < 0 {
+			if err := client.RemoveContact(contact.Id); err != nil {
+				allErrors = errors.Join(allErrors, err)
+				contacts = append(contacts, contact)
+				continue
+			}
+			result.Removed = append(result.Removed, contact)
+			continue
+		}
+		wanted := contactsWanted[index]
+		if wanted.DisplayName == nil || *wanted.DisplayName == contact.DisplayName {
+			contacts = append(contacts, contact)
+			continue
+		}
+		updated, err := client.UpdateContact(contact.Id, wanted)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			contacts = append(contacts, contact)
+			continue
+		}
+		result.Updated = append(result.Updated, *updated)
+		contacts = append(contacts, *updated)
+	}
+	for _, wanted := range contactsWanted {
+		if slices.ContainsFunc(team.Contacts, func(contact Contact) bool { return contact.matches(wanted) }) {
+			continue
+		}
+		added, err := client.AddContact(string(team.Id), wanted)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		result.Added = append(result.Added, *added)
+		contacts = append(contacts, *added)
+	}
+	team.Contacts = contacts
+	return result, allErrors
+}
+
+func (contact Contact) matches(input ContactInput) bool {
+	return contact.Type == input.Type && contact.Address == input.Address
+}
+
 func (team *Team) ResourceId() ID {
 	return team.Id
 }
@@ -1,6 +1,7 @@
 package opslevel_test
 
 import (
+	"strings"
 	"testing"
 
 	ol "github.com/opslevel/opslevel-go/v2024"
@@ -10,7 +11,7 @@ import (
 // Probably should be a feature of autopilot
 func getTestRequestWithAlias() autopilot.TestRequest {
 	return autopilot.NewTestRequest(
-		`query TeamGet($alias:String!){account{team(alias: $alias){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}`,
+		`query TeamGet($alias:String!){account{team(alias: $alias){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}`,
 		`{"alias":"example"}`,
 		`{ "data": {
     "account": {
@@ -93,7 +94,7 @@ func TestCreateTeam(t *testing.T) {
 			ParentTeam:       ol.NewIdentifier("parent_team"),
 		})
 	testRequest := autopilot.NewTestRequest(
-		`mutation TeamCreate($input:TeamCreateInput!){teamCreate(input: $input){team{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},errors{message,path}}}`,
+		`mutation TeamCreate($input:TeamCreateInput!){teamCreate(input: $input){team{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},errors{message,path}}}`,
 		`{"input": {{ template "team_create_input" }} }`,
 		`{ "data": {
     "teamCreate": {
@@ -141,7 +142,7 @@ func TestCreateTeam(t *testing.T) {
 func TestGetTeam(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
+		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
 		`{ {{ template "id1" }} }`,
 		`{ "data": {
     "account": {
@@ -292,7 +293,7 @@ func TestGetTeamWithAlias(t *testing.T) {
 func TestListTeams(t *testing.T) {
 	// Arrange
 	testRequestOne := autopilot.NewTestRequest(
-		`query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
+		`query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
 		`{{ template "pagination_initial_query_variables" }}`,
 		`{ "data": {
       "account": {
@@ -364,7 +365,7 @@ func TestListTeams(t *testing.T) {
         }}}}`,
 	)
 	testRequestTwo := autopilot.NewTestRequest(
-		`query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
+		`query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
 		`{{ template "pagination_second_query_variables" }}`,
 		`{ "data": {
       "account": {
@@ -434,7 +435,7 @@ func TestListTeams(t *testing.T) {
 func TestListTeamsWithManager(t *testing.T) {
 	// Arrange
 	testRequestOne := autopilot.NewTestRequest(
-		`query TeamList($after:String!$email:String!$first:Int!){account{teams(managerEmail: $email, after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
+		`query TeamList($after:String!$email:String!$first:Int!){account{teams(managerEmail: $email, after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
 		`{ "after": "", "first": 100, "email": "kyle@opslevel.com" }`,
 		`{ "data": {
       "account": {
@@ -506,7 +507,7 @@ func TestListTeamsWithManager(t *testing.T) {
         }}}}`,
 	)
 	testRequestTwo := autopilot.NewTestRequest(
-		`query TeamList($after:String!$email:String!$first:Int!){account{teams(managerEmail: $email, after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
+		`query TeamList($after:String!$email:String!$first:Int!){account{teams(managerEmail: $email, after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}`,
 		`{ "after": "OA", "first": 100, "email": "kyle@opslevel.com" }`,
 		`{ "data": {
       "account": {
@@ -583,7 +584,7 @@ func TestUpdateTeam(t *testing.T) {
 		},
 	)
 	testRequest := autopilot.NewTestRequest(
-		`mutation TeamUpdate($input:TeamUpdateInput!){teamUpdate(input: $input){team{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},errors{message,path}}}`,
+		`mutation TeamUpdate($input:TeamUpdateInput!){teamUpdate(input: $input){team{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}},errors{message,path}}}`,
 		`{"input": {{ template "team_update_input" }} }`,
 		`{ "data": {
       "teamUpdate": {
@@ -698,7 +699,7 @@ func TestTeamAddMembership(t *testing.T) {
 func TestTeamRemoveMembership(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`mutation TeamMembershipDelete($input:TeamMembershipDeleteInput!){teamMembershipDelete(input: $input){deletedMembers{id,email,htmlUrl,name,role},errors{message,path}}}`,
+		`mutation TeamMembershipDelete($input:TeamMembershipDeleteInput!){teamMembershipDelete(input: $input){deletedMembers{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
 		`{"input": { "teamId": "{{ template "id1_string" }}", "members": [ { {{ template "team_membership_user_input_1" }} } ] }}`,
 		`{ "data": {
     "teamMembershipDelete": {
@@ -833,7 +834,7 @@ func TestTeamReconcileAliasesDeleteAll(t *testing.T) {
 	)
 	// get team
 	testRequestThree := autopilot.NewTestRequest(
-		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
+		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
 		`{ {{ template "id1" }} }`,
 		`{ "data": { "account": { "team": { {{ template "id1" }}, "aliases": [], "managedAliases": [] }}}}`,
 	)
@@ -868,7 +869,7 @@ func TestTeamReconcileAliasesDeleteSome(t *testing.T) {
 	)
 	// get team
 	testRequestTwo := autopilot.NewTestRequest(
-		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
+		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
 		`{ {{ template "id1" }} }`,
 		`{ "data": { "account": { "team": { {{ template "id1" }}, "aliases": ["two"], "managedAliases": ["two"] }}}}`,
 	)
@@ -921,7 +922,7 @@ func TestTeamReconcileAliases(t *testing.T) {
 	)
 	// get team
 	testRequestFive := autopilot.NewTestRequest(
-		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
+		`query TeamGet($id:ID!){account{team(id: $id){alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}}}}`,
 		`{ {{ template "id1" }} }`,
 		`{ "data": { "account": { "team": { {{ template "id1" }}, "aliases": ["one", "two", "three"], "managedAliases": ["one", "two", "three"] }}}}`,
 	)
@@ -936,3 +937,186 @@ func TestTeamReconcileAliases(t *testing.T) {
 	autopilot.Equals(t, team.Aliases, aliasesWanted)
 	autopilot.Equals(t, team.ManagedAliases, aliasesWanted)
 }
+
+func TestTeamReconcileMemberships(t *testing.T) {
+	// Arrange
+	team := ol.Team{
+		TeamId: ol.TeamId{Alias: "platform", Id: id1},
+		Memberships: &ol.TeamMembershipConnection{Nodes: []ol.TeamMembership{
+			{Role: "member", User: ol.UserId{Id: id2, Email: "alice@example.com"}},
+			{Role: "member", User: ol.UserId{Id: id3, Email: "bob@example.com"}},
+			{Role: "manager", User: ol.UserId{Id: id4, Email: "carol@example.com"}},
+		}},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`mutation TeamMembershipDelete($input:TeamMembershipDeleteInput!){teamMembershipDelete(input: $input){deletedMembers{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
+		`{"input": { "teamId": "{{ template "id1_string" }}", "members": [
+			{ "user": { "email": "alice@example.com" }, "role": "member" },
+			{ "user": { "email": "carol@example.com" }, "role": "manager" }
+		] }}`,
+		`{"data": {"teamMembershipDelete": {"deletedMembers": [ { {{ template "id2" }}, "email": "alice@example.com" }, { {{ template "id4" }}, "email": "carol@example.com" } ], "errors": [] }}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation TeamMembershipCreate($input:TeamMembershipCreateInput!){teamMembershipCreate(input: $input){memberships{role,team{alias,id},user{id,email}},errors{message,path}}}`,
+		`{"input": { "teamId": "{{ template "id1_string" }}", "members": [
+			{ "user": { "email": "dave@example.com" }, "role": "member" },
+			{ "user": { "email": "Alice@example.com" }, "role": "manager" }
+		] }}`,
+		`{"data": {"teamMembershipCreate": {"memberships": [
+			{ "role": "member", "team": { "alias": "platform", {{ template "id1" }} }, "user": { "id": "Z2lkOi8vNTU1", "email": "dave@example.com" } },
+			{ "role": "manager", "team": { "alias": "platform", {{ template "id1" }} }, "user": { {{ template "id2" }}, "email": "alice@example.com" } }
+		], "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "team/reconcile_memberships", requests...)
+	// Act
+	result, err := team.ReconcileMemberships(client, []ol.TeamMembershipUserInput{
+		{User: &ol.UserIdentifierInput{Email: ol.RefOf("Alice@example.com")}, Role: ol.RefOf("manager")},
+		{User: &ol.UserIdentifierInput{Id: &id3}},
+		{User: &ol.UserIdentifierInput{Email: ol.RefOf("dave@example.com")}, Role: ol.RefOf("member")},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Added))
+	autopilot.Equals(t, 1, len(result.Removed))
+	autopilot.Equals(t, "carol@example.com", *result.Removed[0].User.Email)
+	autopilot.Equals(t, 1, len(result.RoleChanged))
+	autopilot.Equals(t, 3, team.Memberships.TotalCount)
+}
+
+func TestTeamReconcileMembershipsNoChanges(t *testing.T) {
+	// Arrange
+	team := ol.Team{
+		TeamId: ol.TeamId{Alias: "platform", Id: id1},
+		Memberships: &ol.TeamMembershipConnection{Nodes: []ol.TeamMembership{
+			{Role: "member", User: ol.UserId{Id: id2, Email: "alice@example.com"}},
+		}},
+	}
+	client := BestTestClient(t, "team/reconcile_memberships_no_changes")
+	// Act
+	result, err := team.ReconcileMemberships(client, []ol.TeamMembershipUserInput{
+		{User: &ol.UserIdentifierInput{Email: ol.RefOf("alice@example.com")}, Role: ol.RefOf("member")},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Assert(t, result.IsEmpty(), "expected no changes")
+}
+
+func TestTeamReconcileMembershipsReportsDroppedUsers(t *testing.T) {
+	// Arrange
+	team := ol.Team{
+		TeamId: ol.TeamId{Alias: "platform", Id: id1},
+		Memberships: &ol.TeamMembershipConnection{Nodes: []ol.TeamMembership{
+			{Role: "member", User: ol.UserId{Id: id2, Email: "alice@example.com"}},
+		}},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`mutation TeamMembershipDelete($input:TeamMembershipDeleteInput!){teamMembershipDelete(input: $input){deletedMembers{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
+		`{"input": { "teamId": "{{ template "id1_string" }}", "members": [ { "user": { "email": "alice@example.com" }, "role": "member" } ] }}`,
+		`{"data": {"teamMembershipDelete": {"deletedMembers": [ { {{ template "id2" }}, "email": "alice@example.com" } ], "errors": [] }}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation TeamMembershipCreate($input:TeamMembershipCreateInput!){teamMembershipCreate(input: $input){memberships{role,team{alias,id},user{id,email}},errors{message,path}}}`,
+		`{"input": { "teamId": "{{ template "id1_string" }}", "members": [ { "user": { "email": "alice@example.com" }, "role": "manager" } ] }}`,
+		`{"data": {"teamMembershipCreate": {"memberships": [], "errors": [ { "message": "Role is not valid", "path": ["members", "0", "role"] } ] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "team/reconcile_memberships_dropped", requests...)
+	// Act
+	result, err := team.ReconcileMemberships(client, []ol.TeamMembershipUserInput{
+		{User: &ol.UserIdentifierInput{Email: ol.RefOf("alice@example.com")}, Role: ol.RefOf("manager")},
+	})
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error")
+	autopilot.Assert(t, strings.Contains(err.Error(), "alice@example.com"), err.Error())
+	autopilot.Equals(t, 1, len(result.Dropped))
+	autopilot.Equals(t, "alice@example.com", *result.Dropped[0].User.Email)
+	autopilot.Equals(t, 0, len(result.RoleChanged))
+	autopilot.Equals(t, 0, team.Memberships.TotalCount)
+}
+
+func TestTeamReconcileMembershipsDuplicateMember(t *testing.T) {
+	// Arrange
+	team := ol.Team{
+		TeamId: ol.TeamId{Alias: "platform", Id: id1},
+		Memberships: &ol.TeamMembershipConnection{Nodes: []ol.TeamMembership{
+			{Role: "member", User: ol.UserId{Id: id2, Email: "alice@example.com"}},
+		}},
+	}
+	client := BestTestClient(t, "team/reconcile_memberships_duplicate_member")
+	// Act
+	_, err := team.ReconcileMemberships(client, []ol.TeamMembershipUserInput{
+		{User: &ol.UserIdentifierInput{Id: &id2}},
+		{User: &ol.UserIdentifierInput{Email: ol.RefOf("alice@example.com")}},
+	})
+	// Assert
+	autopilot.Equals(t, "user 'alice@example.com' is wanted more than once", err.Error())
+}
+
+func TestTeamReconcileMembershipsDuplicateNewUser(t *testing.T) {
+	// Arrange
+	team := ol.Team{
+		TeamId:      ol.TeamId{Alias: "platform", Id: id1},
+		Memberships: &ol.TeamMembershipConnection{},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`query UserGet($input:UserIdentifierInput!){account{user(input: $input){id,email,deactivatedAt,htmlUrl,name,role}}}`,
+		`{"input": { "email": "kyle@opslevel.com" }}`,
+		`{"data": {"account": {"user": {{ template "user_1" }} }}}`,
+	)
+
+	client := BestTestClient(t, "team/reconcile_memberships_duplicate_new_user", testRequestOne)
+	// Act
+	_, err := team.ReconcileMemberships(client, []ol.TeamMembershipUserInput{
+		{User: &ol.UserIdentifierInput{Id: &id1}},
+		{User: &ol.UserIdentifierInput{Email: ol.RefOf("kyle@opslevel.com")}},
+	})
+	// Assert
+	autopilot.Equals(t, "user 'kyle@opslevel.com' is wanted more than once", err.Error())
+}
+
+func TestTeamReconcileContacts(t *testing.T) {
+	// Arrange
+	team := ol.Team{
+		TeamId: ol.TeamId{Alias: "platform", Id: id1},
+		Contacts: []ol.Contact{
+			{Id: id2, Type: ol.ContactTypeSlack, Address: "#platform", DisplayName: "Platform"},
+			{Id: id3, Type: ol.ContactTypeEmail, Address: "old@example.com", DisplayName: "Email"},
+			{Id: id4, Type: ol.ContactTypeWeb, Address: "https://wiki.example.com", DisplayName: "Wiki"},
+		},
+	}
+	testRequestOne := autopilot.NewTestRequest(
+		`mutation ContactUpdate($input:ContactUpdateInput!){contactUpdate(input: $input){contact{address,displayName,displayType,externalId,id,isDefault,type},errors{message,path}}}`,
+		`{"input": { {{ template "id2" }}, "type": "slack", "address": "#platform", "displayName": "Platform Team" }}`,
+		`{"data": {"contactUpdate": {"contact": {"address": "#platform", "displayName": "Platform Team", {{ template "id2" }}, "type": "slack" }, "errors": [] }}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation ContactDelete($input:ContactDeleteInput!){contactDelete(input: $input){deletedContactId,errors{message,path}}}`,
+		`{"input": { {{ template "id3" }} }}`,
+		`{"data": {"contactDelete": {"deletedContactId": "{{ template "id3_string" }}", "errors": [] } }}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation ContactCreate($input:ContactCreateInput!){contactCreate(input: $input){contact{address,displayName,displayType,externalId,id,isDefault,type},errors{message,path}}}`,
+		`{"input": {"type": "email", "address": "platform@example.com", "ownerId": "{{ template "id1_string" }}" }}`,
+		`{"data": {"contactCreate": {"contact": {"address": "platform@example.com", "displayName": "Email", "id": "Z2lkOi8vNTU1", "type": "email"}, "errors": [] } }}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "team/reconcile_contacts", requests...)
+	// Act
+	result, err := team.ReconcileContacts(client, []ol.ContactInput{
+		ol.CreateContactSlack("#platform", ol.RefOf("Platform Team")),
+		ol.CreateContactEmail("platform@example.com", nil),
+		ol.CreateContactWeb("https://wiki.example.com", nil),
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Added))
+	autopilot.Equals(t, 1, len(result.Updated))
+	autopilot.Equals(t, 1, len(result.Removed))
+	autopilot.Equals(t, id3, result.Removed[0].Id)
+	autopilot.Equals(t, 3, len(team.Contacts))
+	autopilot.Equals(t, "Platform Team", team.Contacts[0].DisplayName)
+}
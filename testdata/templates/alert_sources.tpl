@@ -0,0 +1,34 @@
+{{- define "alert_source_request" }}description,externalId,id,integration{id,name,type},locked,name,type,url{{end}}
+{{- define "alert_source_service_request" }}alertSource{ {{- template "alert_source_request" -}} },id,service{id,aliases},status{{end}}
+{{- define "alert_source_1" }}{
+  "description": "Checkout latency",
+  "externalId": "PD-111",
+  "id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VzOjpQYWdlcmR1dHkvMQ",
+  "integration": { {{ template "id2" }}, "name": "PagerDuty", "type": "pagerduty" },
+  "locked": false,
+  "name": "Checkout Latency",
+  "type": "pagerduty",
+  "url": "https://example.pagerduty.com/services/PD-111"
+}{{end}}
+{{- define "alert_source_2" }}{
+  "description": "Checkout errors",
+  "externalId": "DD-222",
+  "id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VzOjpEYXRhZG9nLzI",
+  "integration": { {{ template "id3" }}, "name": "Datadog", "type": "datadog" },
+  "locked": false,
+  "name": "Checkout Errors",
+  "type": "datadog",
+  "url": "https://app.datadoghq.com/monitors/222"
+}{{end}}
+{{- define "alert_source_service_1" }}{
+  "alertSource": {{ template "alert_source_1" }},
+  "id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VTZXJ2aWNlLzE",
+  "service": { {{ template "id1" }}, "aliases": ["example_service"] },
+  "status": "alert"
+}{{end}}
+{{- define "alert_source_service_2" }}{
+  "alertSource": {{ template "alert_source_2" }},
+  "id": "Z2lkOi8vb3BzbGV2ZWwvQWxlcnRTb3VyY2VTZXJ2aWNlLzI",
+  "service": { {{ template "id1" }}, "aliases": ["example_service"] },
+  "status": "ok"
+}{{end}}
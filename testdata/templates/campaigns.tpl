@@ -0,0 +1,62 @@
+{{- define "campaign_request" }}id,name,checkStats{total,totalSuccessful},endedDate,filter{id,name},htmlUrl,owner{alias,id},projectBrief: rawProjectBrief,reminder{channels,daysOfWeek,defaultSlackChannel,frequency,frequencyUnit,message,nextOccurrence,timeOfDay,timezone},serviceStats{total,totalSuccessful},startDate,status,targetDate{{ end }}
+{{- define "campaign_1" }}
+{
+    {{ template "id1" }},
+    "name": "Upgrade to Go 1.22",
+    "checkStats": { "total": 3, "totalSuccessful": 1 },
+    "endedDate": null,
+    "filter": { {{ template "id2" }}, "name": "Go Services" },
+    "htmlUrl": "https://app.opslevel.com/campaigns/1",
+    "owner": { "alias": "platform", {{ template "id3" }} },
+    "projectBrief": "Every go service must be built with go 1.22",
+    "reminder": null,
+    "serviceStats": { "total": 10, "totalSuccessful": 4 },
+    "startDate": "2024-08-01T00:00:00.000Z",
+    "status": "in_progress",
+    "targetDate": "2024-09-01T00:00:00.000Z"
+}
+{{ end }}
+{{- define "campaign_2" }}
+{
+    {{ template "id2" }},
+    "name": "Adopt OpenTelemetry",
+    "checkStats": { "total": 0, "totalSuccessful": 0 },
+    "endedDate": null,
+    "filter": null,
+    "htmlUrl": "https://app.opslevel.com/campaigns/2",
+    "owner": { "alias": "observability", {{ template "id4" }} },
+    "projectBrief": "",
+    "reminder": {
+        "channels": ["slack", "email"],
+        "daysOfWeek": ["monday"],
+        "defaultSlackChannel": "#campaigns",
+        "frequency": 1,
+        "frequencyUnit": "week",
+        "message": "Please instrument your service",
+        "nextOccurrence": "2024-08-05T09:00:00.000Z",
+        "timeOfDay": "09:00",
+        "timezone": "America/Toronto"
+    },
+    "serviceStats": { "total": 0, "totalSuccessful": 0 },
+    "startDate": null,
+    "status": "draft",
+    "targetDate": null
+}
+{{ end }}
+{{- define "campaign_3" }}
+{
+    {{ template "id3" }},
+    "name": "Retire Python 2",
+    "checkStats": { "total": 1, "totalSuccessful": 1 },
+    "endedDate": "2024-07-01T00:00:00.000Z",
+    "filter": null,
+    "htmlUrl": "https://app.opslevel.com/campaigns/3",
+    "owner": { "alias": "platform", {{ template "id3" }} },
+    "projectBrief": "",
+    "reminder": null,
+    "serviceStats": { "total": 2, "totalSuccessful": 2 },
+    "startDate": "2024-05-01T00:00:00.000Z",
+    "status": "ended",
+    "targetDate": "2024-07-01T00:00:00.000Z"
+}
+{{ end }}
@@ -0,0 +1,4 @@
+{{- define "catalog_domain_list_request" }}query DomainsList($after:String!$first:Int!){account{domains(after: $after, first: $first){nodes{id,aliases,description,htmlUrl,managedAliases,name,note,owner{... on Team{teamAlias:alias,id}}},{{ template "pagination_request" }}}}}{{ end }}
+{{- define "catalog_system_list_request" }}query SystemsList($after:String!$first:Int!){account{systems(after: $after, first: $first){nodes{id,aliases,name,description,htmlUrl,owner{... on Team{teamAlias:alias,id}},parent{id,aliases,description,htmlUrl,managedAliases,name,note,owner{... on Team{teamAlias:alias,id}}},note},{{ template "pagination_request" }}}}}{{ end }}
+{{- define "catalog_team_list_request" }}query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{alias,id,aliases,managedAliases,contacts{address,displayName,displayType,externalId,id,isDefault,type},htmlUrl,manager{id,email,deactivatedAt,htmlUrl,name,role},memberships{nodes{role,team{alias,id},user{id,email}},{{ template "pagination_request" }},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}{{ end }}
+{{- define "catalog_service_list_request" }}query ServiceList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{ {{- template "service_request" -}} },{{ template "pagination_request" }},totalCount}}}{{ end }}
@@ -0,0 +1,22 @@
+{{- define "check_result_request" }}check{category{id,name},id,level{alias,description,id,index,name},name},lastEvaluatedAt,message,service{id,aliases},status{{end}}
+{{- define "check_result_1" }}{
+  "check": { "category": {{ template "category_1" }}, "id": "Z2lkOi8vb3BzbGV2ZWwvQ2hlY2tzOjpIYXNPd25lci8x", "level": {{ template "level_2" }}, "name": "Has Owner" },
+  "lastEvaluatedAt": "2026-10-18T09:30:00.000000Z",
+  "message": "Service does not have an owner",
+  "service": { "id": "{{ template "id1_string" }}", "aliases": ["example_service"] },
+  "status": "failed"
+}{{end}}
+{{- define "check_result_2" }}{
+  "check": { "category": {{ template "category_2" }}, "id": "Z2lkOi8vb3BzbGV2ZWwvQ2hlY2tzOjpUYWdEZWZpbmVkLzI", "level": {{ template "level_1" }}, "name": "Has Tier Tag" },
+  "lastEvaluatedAt": "2026-10-18T09:31:00.000000Z",
+  "message": "Tag `tier` is defined",
+  "service": { "id": "{{ template "id1_string" }}", "aliases": ["example_service"] },
+  "status": "passed"
+}{{end}}
+{{- define "check_result_3" }}{
+  "check": { "category": {{ template "category_2" }}, "id": "Z2lkOi8vb3BzbGV2ZWwvQ2hlY2tzOjpSZXBvRmlsZS8z", "level": {{ template "level_3" }}, "name": "Has README" },
+  "lastEvaluatedAt": "2026-10-18T09:32:00.000000Z",
+  "message": "File `README.md` was not found",
+  "service": { "id": "{{ template "id1_string" }}", "aliases": ["example_service"] },
+  "status": "failed"
+}{{end}}
@@ -95,3 +95,4 @@
     ]
 }{{ end }}
 
+{{- define "external_uuid_1" }}a2f2ba73-b4d1-4a2e-9d7c-5a3c2f1e0b9d{{ end }}
@@ -230,3 +230,22 @@
   {{ template "second_page_variables" }}
 }
 {{ end }}
+{{- define "custom_actions_trigger_event_request" }}{definition{aliases,id},id,manualInputs,responseBody,status,timestamps{createdAt,updatedAt},triggeredBy{id,email}}{{ end }}
+{{- define "custom_action_trigger_event_pending" }}{
+  "definition": { "aliases": ["rollback"], "id": "{{ template "id1_string" }}" },
+  "id": "{{ template "id2_string" }}",
+  "manualInputs": { "reason": "bad deploy" },
+  "responseBody": null,
+  "status": "PENDING",
+  "timestamps": { "createdAt": "2026-10-19T14:00:00.000000Z", "updatedAt": "2026-10-19T14:00:00.000000Z" },
+  "triggeredBy": { {{ template "user_id_email_1" }} }
+}{{ end }}
+{{- define "custom_action_trigger_event_success" }}{
+  "definition": { "aliases": ["rollback"], "id": "{{ template "id1_string" }}" },
+  "id": "{{ template "id2_string" }}",
+  "manualInputs": { "reason": "bad deploy" },
+  "responseBody": "{\"pipeline\": 42}",
+  "status": "SUCCESS",
+  "timestamps": { "createdAt": "2026-10-19T14:00:00.000000Z", "updatedAt": "2026-10-19T14:00:05.000000Z" },
+  "triggeredBy": { {{ template "user_id_email_1" }} }
+}{{ end }}
@@ -0,0 +1,18 @@
+{{- define "relationship_resource_request" }}__typename,... on Domain{id,aliases},... on InfrastructureResource{id,aliases},... on Service{id,aliases},... on System{id,aliases}{{end}}
+{{- define "related_resources_request" }}relatedResources(after: $after, first: $first){edges{node{ {{- template "relationship_resource_request" }}},relationshipId,relationshipType},{{ template "pagination_request" }},totalCount}{{end}}
+{{- define "relationship_1" }}{
+  "id": "{{ template "id1_string" }}",
+  "source": { "__typename": "Service", "id": "{{ template "id2_string" }}", "aliases": ["example_service"] },
+  "target": { "__typename": "System", "id": "{{ template "id3_string" }}", "aliases": ["example_system"] },
+  "type": "belongs_to"
+}{{end}}
+{{- define "related_resource_1" }}{
+  "node": { "__typename": "System", "id": "{{ template "id3_string" }}", "aliases": ["example_system"] },
+  "relationshipId": "{{ template "id1_string" }}",
+  "relationshipType": "belongs_to"
+}{{end}}
+{{- define "related_resource_2" }}{
+  "node": { "__typename": "InfrastructureResource", "id": "{{ template "id4_string" }}", "aliases": ["example_database"] },
+  "relationshipId": "{{ template "id2_string" }}",
+  "relationshipType": "depends_on"
+}{{end}}
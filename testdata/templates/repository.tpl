@@ -113,3 +113,32 @@
 "visible": true
 }
 {{ end }}
+{{- define "repository_request" }}archivedAt,createdOn,defaultAlias,defaultBranch,description,forked,htmlUrl,id,languages{name,usage},lastOwnerChangedAt,locked,name,organization,owner{alias,id},private,repoKey,services{edges{atRoot,node{id,aliases},paths{href,path},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},type,url,visible{{ end }}
+{{- define "repository_hidden_1" }}
+{
+"archivedAt": "2024-01-10T12:00:00.000000Z",
+"defaultAlias": "github.com:OpsLevel/legacy-billing",
+"forked": false,
+{{ template "id2" }},
+"name": "legacy-billing",
+"organization": "OpsLevel",
+"private": true,
+"services": { "edges": [], "totalCount": 0 },
+"type": "GitHub",
+"visible": false
+}
+{{ end }}
+{{- define "repository_hidden_2" }}
+{
+"archivedAt": null,
+"defaultAlias": "github.com:OpsLevel/sandbox-experiments",
+"forked": true,
+{{ template "id3" }},
+"name": "sandbox-experiments",
+"organization": "OpsLevel",
+"private": false,
+"services": { "edges": [ { "atRoot": true, "node": { {{ template "id1" }}, "aliases": ["example_service"] } } ], "totalCount": 1 },
+"type": "GitHub",
+"visible": false
+}
+{{ end }}
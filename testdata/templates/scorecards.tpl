@@ -56,6 +56,8 @@ query ScorecardGet($input:IdentifierInput!){account{scorecard(input: $input){ali
 
 {{- define "scorecard_list_query" }}query ScorecardsList($after:String!$first:Int!){account{scorecards(after: $after, first: $first){nodes{aliases,id,affectsOverallServiceLevels,description,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},name,owner{... on Team{teamAlias:alias,id}},passingChecks,serviceCount,totalChecks},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}{{ end }}
 
+{{- define "scorecard_sorted_list_query" }}query ScorecardsListWithSort($after:String!$first:Int!$sortBy:ScorecardSortEnum!){account{scorecards(sortBy: $sortBy, after: $after, first: $first){nodes{aliases,id,affectsOverallServiceLevels,description,filter{id,name,connective,htmlUrl,predicates{key,keyData,type,value,caseSensitive}},name,owner{... on Team{teamAlias:alias,id}},passingChecks,serviceCount,totalChecks},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}{{ end }}
+
 {{- define "scorecard_1_response" }}
     "id":"Z2lkOi8vMTExMTExMTEK",
     "aliases":["first_scorecard"],
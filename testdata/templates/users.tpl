@@ -59,3 +59,11 @@
 "email": "example@opslevel.com"
 }
 {{ end }}
+{{- define "user_deactivated_1" }}
+{
+{{ template "user_id_email_1" }},
+"deactivatedAt": "2026-09-01T12:00:00.000000Z",
+"name": "Kyle Rockman",
+"role": "user"
+}
+{{ end }}
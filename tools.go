@@ -1,5 +1,11 @@
 package opslevel
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
 type Tool struct {
 	Category      ToolCategory
 	CategoryAlias string `json:",omitempty"`
@@ -56,3 +62,137 @@ func (client *Client) DeleteTool(id ID) error {
 	err := client.Mutate(&m, v, WithName("ToolDelete"))
 	return HandleErrors(err, m.Payload.Errors)
 }
+
+// ToolSpec is the desired state of a tool, tools on a service are matched by category, display name and environment
+type ToolSpec struct {
+	Category    ToolCategory `json:"category" yaml:"category"`
+	DisplayName string       `json:"displayName" yaml:"displayName"`
+	Url         string       `json:"url" yaml:"url"`
+	Environment string       `json:"environment,omitempty" yaml:"environment,omitempty"`
+}
+
+// ToolDiff lists the changes that make a service's tools match the desired tools
+type ToolDiff struct {
+	Create []ToolCreateInput
+	Update []ToolUpdateInput // Only the Url of a matched tool is ever updated
+	Delete []Tool
+}
+
+func (diff *ToolDiff) IsEmpty() bool {
+	return len(diff.Create) == 0 && len(diff.Update) == 0 && len(diff.Delete) == 0
+}
+
+// DiffTools compares the service's loaded tools with 'toolsWanted' without calling the API, use it as a dry run of ReconcileTools
+//
+// Tools are matched on category, display name and environment. When several existing tools match the
+// same wanted tool, the one with the wanted url is kept (else the first one) and the others are deleted.
+func (service *Service) DiffTools(toolsWanted []ToolSpec) (*ToolDiff, error) {
+	var existing []Tool
+	if service.Tools != nil {
+		existing = service.Tools.Nodes
+	}
+	diff := &ToolDiff{}
+	seen := map[string]bool{}
+	kept := map[int]bool{}
+	for _, spec := range toolsWanted {
+		if !slices.Contains(AllToolCategory, string(spec.Category)) {
+			return nil, fmt.Errorf("tool '%s' category must be one of %v. Given: '%s'", spec.DisplayName, AllToolCategory, spec.Category)
+		}
+		key := spec.key()
+		if seen[key] {
+			return nil, fmt.Errorf("tool '%s' is wanted more than once", key)
+		}
+		seen[key] = true
+
+		index := slices.IndexFunc(existing, func(tool Tool) bool { return spec.matches(tool) && tool.Url == spec.Url })
+		if index < 0 {
+			index = slices.IndexFunc(existing, spec.matches)
+		}
+		if index < 0 {
+			diff.Create = append(diff.Create, ToolCreateInput{
+				Category:    spec.Category,
+				DisplayName: spec.DisplayName,
+				Url:         spec.Url,
+				Environment: nilIfEmpty(spec.Environment),
+				ServiceId:   RefOf(service.Id),
+			})
+			continue
+		}
+		kept[index] = true
+		if existing[index].Url != spec.Url {
+			diff.Update = append(diff.Update, ToolUpdateInput{Id: existing[index].Id, Url: RefOf(spec.Url)})
+		}
+	}
+	for index, tool := range existing {
+		if !kept[index] {
+			diff.Delete = append(diff.Delete, tool)
+		}
+	}
+	return diff, nil
+}
+
+// ReconcileTools manages the tools of a service
+//
+// Tools not in 'toolsWanted' will be deleted, urls of matching tools will be updated in place
+// and new tools from 'toolsWanted' will be created. Returns the changes that were made.
+func (client *Client) ReconcileTools(service *Service, toolsWanted []ToolSpec) (*ToolDiff, error) {
+	if service.Id == "" {
+		return nil, fmt.Errorf("unable to reconcile tools, invalid service id: '%s'", service.Id)
+	}
+	if service.Tools == nil || service.Tools.PageInfo.HasNextPage {
+		service.Tools = nil
+		if _, err := service.GetTools(client, nil); err != nil {
+			return nil, err
+		}
+	}
+	diff, err := service.DiffTools(toolsWanted)
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors error
+	applied := &ToolDiff{}
+	for _, tool := range diff.Delete {
+		if err := client.DeleteTool(tool.Id); err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		applied.Delete = append(applied.Delete, tool)
+		service.Tools.Nodes = slices.DeleteFunc(service.Tools.Nodes, func(t Tool) bool { return t.Id == tool.Id })
+	}
+	for _, input := range diff.Update {
+		tool, err := client.UpdateTool(input)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		applied.Update = append(applied.Update, input)
+		if index := slices.IndexFunc(service.Tools.Nodes, func(t Tool) bool { return t.Id == input.Id }); index >= 0 {
+			service.Tools.Nodes[index].Url = tool.Url
+		}
+	}
+	for _, input := range diff.Create {
+		tool, err := client.CreateTool(input)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+		applied.Create = append(applied.Create, input)
+		service.Tools.Nodes = append(service.Tools.Nodes, *tool)
+	}
+	service.Tools.TotalCount = len(service.Tools.Nodes)
+	return applied, allErrors
+}
+
+func (spec ToolSpec) key() string {
+	return toolKey(spec.Category, spec.DisplayName, spec.Environment)
+}
+
+func (spec ToolSpec) matches(tool Tool) bool {
+	return spec.key() == toolKey(tool.Category, tool.DisplayName, tool.Environment)
+}
+
+// toolKey identifies a tool on a service by category, display name and environment
+func toolKey(category ToolCategory, displayName string, environment string) string {
+	return fmt.Sprintf("%s/%s/%s", category, displayName, environment)
+}
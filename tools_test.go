@@ -1,6 +1,8 @@
 package opslevel_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	ol "github.com/opslevel/opslevel-go/v2024"
@@ -56,3 +58,125 @@ func TestDeleteTool(t *testing.T) {
 	// Assert
 	autopilot.Ok(t, err)
 }
+
+func TestServiceDiffTools(t *testing.T) {
+	// Arrange
+	service := ol.Service{
+		ServiceId: ol.ServiceId{Id: id1},
+		Tools: &ol.ToolConnection{Nodes: []ol.Tool{
+			{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Environment: "prod", Id: id2, Url: "https://old.example.com"},
+			{Category: ol.ToolCategoryLogs, DisplayName: "Kibana", Id: id3, Url: "https://kibana.example.com"},
+			{Category: ol.ToolCategoryCode, DisplayName: "GitHub", Id: id4, Url: "https://github.com/example"},
+		}},
+	}
+	// Act
+	diff, err := service.DiffTools([]ol.ToolSpec{
+		{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Environment: "prod", Url: "https://new.example.com"},
+		{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Environment: "staging", Url: "https://staging.example.com"},
+		{Category: ol.ToolCategoryCode, DisplayName: "GitHub", Url: "https://github.com/example"},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.ToolCreateInput{{
+		Category:    ol.ToolCategoryMetrics,
+		DisplayName: "Datadog",
+		Environment: ol.RefOf("staging"),
+		Url:         "https://staging.example.com",
+		ServiceId:   &id1,
+	}}, diff.Create)
+	autopilot.Equals(t, []ol.ToolUpdateInput{{Id: id2, Url: ol.RefOf("https://new.example.com")}}, diff.Update)
+	autopilot.Equals(t, 1, len(diff.Delete))
+	autopilot.Equals(t, id3, diff.Delete[0].Id)
+}
+
+func TestServiceDiffToolsDuplicates(t *testing.T) {
+	// Arrange
+	id5 := ol.ID("Z2lkOi8vb3BzbGV2ZWwvVG9vbC81")
+	service := ol.Service{
+		ServiceId: ol.ServiceId{Id: id1},
+		Tools: &ol.ToolConnection{Nodes: []ol.Tool{
+			{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Environment: "prod", Id: id2, Url: "https://old.example.com"},
+			{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Environment: "prod", Id: id3, Url: "https://new.example.com"},
+			{Category: ol.ToolCategoryLogs, DisplayName: "Kibana", Id: id4, Url: "https://one.example.com"},
+			{Category: ol.ToolCategoryLogs, DisplayName: "Kibana", Id: id5, Url: "https://two.example.com"},
+		}},
+	}
+	// Act
+	diff, err := service.DiffTools([]ol.ToolSpec{
+		{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Environment: "prod", Url: "https://new.example.com"},
+		{Category: ol.ToolCategoryLogs, DisplayName: "Kibana", Url: "https://kibana.example.com"},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 0, len(diff.Create))
+	autopilot.Equals(t, []ol.ToolUpdateInput{{Id: id4, Url: ol.RefOf("https://kibana.example.com")}}, diff.Update)
+	autopilot.Equals(t, 2, len(diff.Delete))
+	autopilot.Equals(t, id2, diff.Delete[0].Id)
+	autopilot.Equals(t, id5, diff.Delete[1].Id)
+}
+
+func TestServiceDiffToolsInvalid(t *testing.T) {
+	// Arrange
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	testCases := map[string][]ol.ToolSpec{
+		"tool 'Nope' category must be one of": {
+			{Category: "nope", DisplayName: "Nope", Url: "https://example.com"},
+		},
+		"tool 'metrics/Datadog/' is wanted more than once": {
+			{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Url: "https://one.example.com"},
+			{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Url: "https://two.example.com"},
+		},
+	}
+	for expected, tools := range testCases {
+		t.Run(expected, func(t *testing.T) {
+			// Act
+			_, err := service.DiffTools(tools)
+			// Assert
+			autopilot.Assert(t, err != nil && strings.HasPrefix(err.Error(), expected), fmt.Sprintf("unexpected error: %v", err))
+		})
+	}
+}
+
+func TestReconcileTools(t *testing.T) {
+	// Arrange
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	testRequestOne := autopilot.NewTestRequest(
+		`query ServiceToolsList($after:String!$first:Int!$service:ID!){account{service(id: $service){tools(after: $after, first: $first){nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}}}}`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{ "data": { "account": { "service": { "tools": { "nodes": [
+			{ "category": "metrics", "displayName": "Datadog", "environment": "prod", {{ template "id2" }}, "url": "https://old.example.com" },
+			{ "category": "logs", "displayName": "Kibana", {{ template "id3" }}, "url": "https://kibana.example.com" }
+		], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+	testRequestTwo := autopilot.NewTestRequest(
+		`mutation ToolDelete($input:ToolDeleteInput!){toolDelete(input: $input){errors{message,path}}}`,
+		`{ "input": { {{ template "id3" }} } }`,
+		`{"data": { "toolDelete": { "errors": [] }}}`,
+	)
+	testRequestThree := autopilot.NewTestRequest(
+		`mutation ToolUpdate($input:ToolUpdateInput!){toolUpdate(input: $input){tool{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},errors{message,path}}}`,
+		`{ "input": { {{ template "id2" }}, "url": "https://new.example.com" } }`,
+		`{"data": { "toolUpdate": { "tool": { "category": "metrics", "displayName": "Datadog", "environment": "prod", {{ template "id2" }}, "url": "https://new.example.com" }, "errors": [] }}}`,
+	)
+	testRequestFour := autopilot.NewTestRequest(
+		`mutation ToolCreate($input:ToolCreateInput!){toolCreate(input: $input){tool{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},errors{message,path}}}`,
+		`{ "input": { "category": "code", "displayName": "GitHub", "url": "https://github.com/example", "serviceId": "{{ template "id1_string" }}" } }`,
+		`{"data": { "toolCreate": { "tool": { "category": "code", "displayName": "GitHub", {{ template "id4" }}, "url": "https://github.com/example" }, "errors": [] }}}`,
+	)
+	requests := []autopilot.TestRequest{testRequestOne, testRequestTwo, testRequestThree, testRequestFour}
+
+	client := BestTestClient(t, "tools/reconcile", requests...)
+	// Act
+	result, err := client.ReconcileTools(&service, []ol.ToolSpec{
+		{Category: ol.ToolCategoryMetrics, DisplayName: "Datadog", Environment: "prod", Url: "https://new.example.com"},
+		{Category: ol.ToolCategoryCode, DisplayName: "GitHub", Url: "https://github.com/example"},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Create))
+	autopilot.Equals(t, 1, len(result.Update))
+	autopilot.Equals(t, 1, len(result.Delete))
+	autopilot.Equals(t, 2, service.Tools.TotalCount)
+	autopilot.Equals(t, "https://new.example.com", service.Tools.Nodes[0].Url)
+	autopilot.Equals(t, id4, service.Tools.Nodes[1].Id)
+}
@@ -3,6 +3,9 @@ package opslevel
 import (
 	"fmt"
 	"slices"
+	"strings"
+
+	"github.com/relvacode/iso8601"
 )
 
 type UserId struct {
@@ -12,9 +15,10 @@ type UserId struct {
 
 type User struct {
 	UserId
-	HTMLUrl string
-	Name    string
-	Role    UserRole
+	DeactivatedAt *iso8601.Time
+	HTMLUrl       string
+	Name          string
+	Role          UserRole
 	// We cannot have this here because its breaks a TON of queries
 	// Teams   *TeamIdConnection
 }
@@ -33,6 +37,10 @@ func (user *User) ResourceType() TaggableResource {
 	return TaggableResourceUser
 }
 
+func (user *User) IsDeactivated() bool {
+	return user.DeactivatedAt != nil
+}
+
 func NewUserIdentifier(value string) *UserIdentifierInput {
 	if IsID(value) {
 		return &UserIdentifierInput{
@@ -167,6 +175,37 @@ func (client *Client) ListUsers(variables *PayloadVariables) (*UserConnection, e
 	return &q.Account.Users, nil
 }
 
+func (client *Client) ListUsersWithFilter(filter []UsersFilterInput, variables *PayloadVariables) (*UserConnection, error) {
+	var q struct {
+		Account struct {
+			Users UserConnection `graphql:"users(filter: $filter, after: $after, first: $first)"`
+		}
+	}
+	for _, item := range filter {
+		if !slices.Contains(AllUsersFilterEnum, string(item.Key)) {
+			return nil, fmt.Errorf("users filter key '%s' must be one of %v", item.Key, AllUsersFilterEnum)
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["filter"] = filter
+	if err := client.Query(&q, *variables, WithName("UserListWithFilter")); err != nil {
+		return nil, err
+	}
+	for q.Account.Users.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Users.PageInfo.End
+		resp, err := client.ListUsersWithFilter(filter, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Users.Nodes = append(q.Account.Users.Nodes, resp.Nodes...)
+		q.Account.Users.PageInfo = resp.PageInfo
+		q.Account.Users.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Users, nil
+}
+
 func (client *Client) UpdateUser(user string, input UserInput) (*User, error) {
 	var m struct {
 		Payload struct {
@@ -194,3 +233,52 @@ func (client *Client) DeleteUser(user string) error {
 	err := client.Mutate(&m, v, WithName("UserDelete"))
 	return HandleErrors(err, m.Payload.Errors)
 }
+
+func (client *Client) DeactivateUser(user string) (*User, error) {
+	var m struct {
+		Payload struct {
+			User   User
+			Errors []OpsLevelErrors
+		} `graphql:"userDeactivate(user: $user)"`
+	}
+	v := PayloadVariables{
+		"user": *NewUserIdentifier(user),
+	}
+	err := client.Mutate(&m, v, WithName("UserDeactivate"))
+	return &m.Payload.User, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) ReactivateUser(user string) (*User, error) {
+	var m struct {
+		Payload struct {
+			User   User
+			Errors []OpsLevelErrors
+		} `graphql:"userReactivate(user: $user)"`
+	}
+	v := PayloadVariables{
+		"user": *NewUserIdentifier(user),
+	}
+	err := client.Mutate(&m, v, WithName("UserReactivate"))
+	return &m.Payload.User, HandleErrors(err, m.Payload.Errors)
+}
+
+// matches is true when 'input' identifies this user by id or, ignoring case, by email
+func (userId UserId) matches(input *UserIdentifierInput) bool {
+	if input == nil {
+		return false
+	}
+	if input.Id != nil && *input.Id != "" {
+		return userId.Id == *input.Id
+	}
+	return input.Email != nil && strings.EqualFold(userId.Email, *input.Email)
+}
+
+func (input *UserIdentifierInput) String() string {
+	if input.Email != nil {
+		return *input.Email
+	}
+	if input.Id != nil {
+		return string(*input.Id)
+	}
+	return ""
+}
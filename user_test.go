@@ -10,7 +10,7 @@ import (
 func TestInviteUser(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`mutation UserInvite($email:String!$input:UserInput!){userInvite(email: $email input: $input){user{id,email,htmlUrl,name,role},errors{message,path}}}`,
+		`mutation UserInvite($email:String!$input:UserInput!){userInvite(email: $email input: $input){user{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
 		`{"email": "kyle@opslevel.com", "input": { "name": "Kyle Rockman", "skipWelcomeEmail": false }}`,
 		`{"data": { "userInvite": { "user": {{ template "user_1" }}, "errors": [] }}}`,
 	)
@@ -31,7 +31,7 @@ func TestInviteUser(t *testing.T) {
 func TestGetUser(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`query UserGet($input:UserIdentifierInput!){account{user(input: $input){id,email,htmlUrl,name,role}}}`,
+		`query UserGet($input:UserIdentifierInput!){account{user(input: $input){id,email,deactivatedAt,htmlUrl,name,role}}}`,
 		`{"input": { "email": "kyle@opslevel.com" }}`,
 		`{"data": {"account": {"user": {{ template "user_1" }} }}}`,
 	)
@@ -79,12 +79,12 @@ func TestGetUserTeams(t *testing.T) {
 func TestListUser(t *testing.T) {
 	// Arrange
 	testRequestOne := autopilot.NewTestRequest(
-		`query UserList($after:String!$first:Int!){account{users(after: $after, first: $first){nodes{id,email,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}`,
+		`query UserList($after:String!$first:Int!){account{users(after: $after, first: $first){nodes{id,email,deactivatedAt,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}`,
 		`{{ template "pagination_initial_query_variables" }}`,
 		`{ "data": { "account": { "users": { "nodes": [ {{ template "user_1" }}, {{ template "user_2" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 2 }}}}`,
 	)
 	testRequestTwo := autopilot.NewTestRequest(
-		`query UserList($after:String!$first:Int!){account{users(after: $after, first: $first){nodes{id,email,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}`,
+		`query UserList($after:String!$first:Int!){account{users(after: $after, first: $first){nodes{id,email,deactivatedAt,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}`,
 		`{{ template "pagination_second_query_variables" }}`,
 		`{ "data": { "account": { "users": { "nodes": [ {{ template "user_3" }} ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}`,
 	)
@@ -103,10 +103,40 @@ func TestListUser(t *testing.T) {
 	autopilot.Equals(t, ol.UserRoleAdmin, result[2].Role)
 }
 
+func TestListUserWithFilter(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`query UserListWithFilter($after:String!$filter:[UsersFilterInput!]!$first:Int!){account{users(filter: $filter, after: $after, first: $first){nodes{id,email,deactivatedAt,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}`,
+		`{ {{ template "first_page_variables" }}, "filter": [ { "key": "role", "arg": "admin", "type": "equals" }, { "key": "deactivated_at", "type": "does_not_equal" } ] }`,
+		`{ "data": { "account": { "users": { "nodes": [ {{ template "user_deactivated_1" }} ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+
+	client := BestTestClient(t, "user/list_with_filter", testRequest)
+	// Act
+	response, err := client.ListUsersWithFilter([]ol.UsersFilterInput{
+		{Key: ol.UsersFilterEnumRole, Arg: ol.RefOf("admin"), Type: ol.RefOf(ol.BasicTypeEnumEquals)},
+		{Key: ol.UsersFilterEnumDeactivatedAt, Type: ol.RefOf(ol.BasicTypeEnumDoesNotEqual)},
+	}, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, response.TotalCount)
+	autopilot.Equals(t, "Kyle Rockman", response.Nodes[0].Name)
+	autopilot.Equals(t, true, response.Nodes[0].IsDeactivated())
+}
+
+func TestListUserWithFilterInvalidKey(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "user/list_with_filter_invalid")
+	// Act
+	_, err := client.ListUsersWithFilter([]ol.UsersFilterInput{{Key: ol.UsersFilterEnum("team")}}, nil)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown filter key")
+}
+
 func TestUpdateUser(t *testing.T) {
 	// Arrange
 	testRequest := autopilot.NewTestRequest(
-		`mutation UserUpdate($input:UserInput!$user:UserIdentifierInput!){userUpdate(user: $user input: $input){user{id,email,htmlUrl,name,role},errors{message,path}}}`,
+		`mutation UserUpdate($input:UserInput!$user:UserIdentifierInput!){userUpdate(user: $user input: $input){user{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
 		`{"input": {"role": "admin", "skipWelcomeEmail": false }, "user": {"email": "kyle@opslevel.com" }}`,
 		`{"data": {"userUpdate": {"user": {{ template "user_1_update" }}, "errors": [] }}}`,
 	)
@@ -236,3 +266,37 @@ func TestGetUserTags(t *testing.T) {
 	autopilot.Equals(t, "captain", result[3].Key)
 	autopilot.Equals(t, "tuna", result[3].Value)
 }
+
+func TestDeactivateUser(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation UserDeactivate($user:UserIdentifierInput!){userDeactivate(user: $user){user{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
+		`{"user": {"email": "kyle@opslevel.com" }}`,
+		`{"data": {"userDeactivate": {"user": {{ template "user_deactivated_1" }}, "errors": [] }}}`,
+	)
+
+	client := BestTestClient(t, "user/deactivate", testRequest)
+	// Act
+	result, err := client.DeactivateUser("kyle@opslevel.com")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id1, result.Id)
+	autopilot.Equals(t, true, result.IsDeactivated())
+}
+
+func TestReactivateUser(t *testing.T) {
+	// Arrange
+	testRequest := autopilot.NewTestRequest(
+		`mutation UserReactivate($user:UserIdentifierInput!){userReactivate(user: $user){user{id,email,deactivatedAt,htmlUrl,name,role},errors{message,path}}}`,
+		`{"user": { {{ template "id1" }} }}`,
+		`{"data": {"userReactivate": {"user": {{ template "user_1" }}, "errors": [] }}}`,
+	)
+
+	client := BestTestClient(t, "user/reactivate", testRequest)
+	// Act
+	result, err := client.ReactivateUser(string(id1))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Kyle Rockman", result.Name)
+	autopilot.Equals(t, false, result.IsDeactivated())
+}